@@ -15,6 +15,7 @@ type (
 		Graph() GraphClient
 		Meta() MetaClient
 		StorageAdmin() StorageAdminClient
+		Storage() StorageClient
 		Factory() Factory
 		Version() Version
 	}
@@ -38,6 +39,7 @@ type (
 		graph          *driverGraph
 		meta           *driverMeta
 		storageAdmin   *driverStorageAdmin
+		storage        *driverStorage
 	}
 )
 
@@ -56,6 +58,7 @@ func NewClient(info ConnectionInfo, opts ...Option) (Client, error) {
 		graph:        newDriverGraph(info.GraphEndpoints, info.GraphAccount.Username, info.GraphAccount.Password, &o.graph),
 		meta:         newDriverMeta(info.MetaEndpoints, &o.meta),
 		storageAdmin: newDriverStorageAdmin(info.StorageAdminEndpoints, &o.storageAdmin),
+		storage:      newDriverStorage(&o.storageAdmin),
 	}, nil
 }
 
@@ -71,6 +74,10 @@ func (c *defaultClient) StorageAdmin() StorageAdminClient {
 	return (*defaultStorageAdminClient)(c)
 }
 
+func (c *defaultClient) Storage() StorageClient {
+	return (*defaultStorageClient)(c)
+}
+
 func (c *defaultClient) Factory() Factory {
 	f, _ := NewFactory(WithVersion(c.o.version))
 	return f
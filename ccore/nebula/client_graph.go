@@ -1,6 +1,12 @@
 package nebula
 
 import (
+	"net"
+	"strings"
+	"time"
+
+	"github.com/facebook/fbthrift/thrift/lib/go/thrift"
+	nerrors "github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/errors"
 	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
 	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/wrapper"
 )
@@ -16,6 +22,9 @@ type (
 		Factory() Factory
 		Version() Version
 		GetTimezoneInfo() types.TimezoneInfo
+		GetSessionId() int64
+		// SetPassword sets the password to authenticate the new sessions with after it's changed
+		SetPassword(password string)
 	}
 
 	defaultGraphClient defaultClient
@@ -39,9 +48,18 @@ func (c *defaultGraphClient) GetTimezoneInfo() types.TimezoneInfo {
 	return c.graph.GetTimezoneInfo()
 }
 
+func (c *defaultGraphClient) GetSessionId() int64 {
+	return c.graph.sessionId
+}
+
+func (c *defaultGraphClient) SetPassword(password string) {
+	c.graph.setPassword(password)
+}
+
+// Open connects to the graph endpoints and authenticates, it does nothing if the client is open already
 func (c *defaultGraphClient) Open() error {
 	return c.defaultClient().initDriver(func(driver types.Driver) error {
-		return c.graph.open(driver)
+		return c.openRetry(driver)
 	})
 }
 
@@ -49,12 +67,23 @@ func (c *defaultGraphClient) Authenticate(username, password string) (AuthRespon
 	return c.graph.Authenticate(username, password)
 }
 
-func (c *defaultGraphClient) Execute(stmt []byte) (ExecutionResponse, error) {
-	return c.graph.Execute(c.graph.sessionId, stmt)
+func (c *defaultGraphClient) Execute(stmt []byte) (resp ExecutionResponse, err error) {
+	err = c.retryDo(stmt, func() error {
+		resp, err = c.graph.Execute(c.graph.sessionId, stmt)
+		return err
+	})
+	if err == nil {
+		c.graph.updateSpace(resp)
+	}
+	return
 }
 
-func (c *defaultGraphClient) ExecuteJson(stmt []byte) ([]byte, error) {
-	return c.graph.ExecuteJson(c.graph.sessionId, stmt)
+func (c *defaultGraphClient) ExecuteJson(stmt []byte) (resp []byte, err error) {
+	err = c.retryDo(stmt, func() error {
+		resp, err = c.graph.ExecuteJson(c.graph.sessionId, stmt)
+		return err
+	})
+	return
 }
 
 func (c *defaultGraphClient) ExecuteWithParameter(stmt []byte, params types.ParameterMap) (ExecutionResponse, error) {
@@ -70,7 +99,93 @@ func (c *defaultGraphClient) ExecuteWithParameter(stmt []byte, params types.Para
 		}
 		paramsMap[k] = nv
 	}
-	return c.graph.ExecuteWithParameter(c.graph.sessionId, stmt, paramsMap)
+	var resp ExecutionResponse
+	err := c.retryDo(stmt, func() (err error) {
+		// the parameters are sent with every statement, so they are restored on the new session as well
+		resp, err = c.graph.ExecuteWithParameter(c.graph.sessionId, stmt, paramsMap)
+		return err
+	})
+	if err == nil {
+		c.graph.updateSpace(resp)
+	}
+	return resp, err
+}
+
+/*
+`retryDo` reconnects to the graph endpoints with the reconnect policy if the connection is broken or timed out,
+and runs fn again if it's an idempotent read statement which did not time out
+*/
+func (c *defaultGraphClient) retryDo(stmt []byte, fn func() error) error {
+	// the last reconnection may have failed
+	if c.graph.GraphClientDriver == nil {
+		if err := c.reconnect(); err != nil {
+			return err
+		}
+	}
+	err := fn()
+	if err == nil || !isConnectionError(err) || c.o.reconnect.MaxAttempts <= 0 {
+		return err
+	}
+
+	// the statement may have been executed even if the connection is broken while sending it,
+	// so a write is never run again, and a timed out read may be still running
+	timeout := isTimeout(err)
+	retryable := isIdempotentRead(stmt) && !timeout
+	c.graph.reset()
+	// a timed out graphd is alive but slow, so it's not a reason to fail over to the next one
+	if !timeout {
+		c.graph.connection.UpdateNextIndex()
+	}
+	if reconnectErr := c.reconnect(); reconnectErr != nil {
+		return reconnectErr
+	}
+	if timeout {
+		return err
+	}
+	if !retryable {
+		return nerrors.ErrStatementNotRetried
+	}
+	return fn()
+}
+
+/*
+`reconnect` opens a new session with the reconnect policy, which may be on another graphd,
+and signs out the abandoned one through it, the sign out is best effort since the abandoned session may be gone already
+*/
+func (c *defaultGraphClient) reconnect() error {
+	policy := c.o.reconnect
+	abandoned := c.graph.sessionId
+	err := nerrors.ErrNoValidGraphEndpoint
+	for i := 0; i < policy.MaxAttempts; i++ {
+		time.Sleep(policy.backoff(i))
+		if err = c.openRetry(c.driver); err == nil {
+			if abandoned != 0 && abandoned != c.graph.sessionId {
+				c.graph.Signout(abandoned)
+			}
+			return c.graph.restoreSpace()
+		}
+		if err != nerrors.ErrNoValidGraphEndpoint {
+			return err
+		}
+	}
+	return err
+}
+
+func (c *defaultGraphClient) openRetry(driver types.Driver) error {
+	n := c.graph.connection.GetEndpointsLen()
+	for i := 0; i < n; i++ {
+		// an open session is kept, the broken ones are reset before reconnecting
+		err := c.graph.open(driver)
+		if err == nil {
+			return nil
+		}
+		// the other endpoints would reject it for the same reason, e.g. authentication failed
+		if !isConnectionError(err) {
+			return err
+		}
+		c.graph.connection.UpdateNextIndex() // update nextIndex when connect failed
+	}
+	return nerrors.ErrNoValidGraphEndpoint
 }
 
 func (c *defaultGraphClient) Close() error {
@@ -88,3 +203,59 @@ func (c *defaultGraphClient) Version() Version {
 func (c *defaultGraphClient) defaultClient() *defaultClient {
 	return (*defaultClient)(c)
 }
+
+// idempotentReadKeywords are the beginnings of the statements which don't change anything
+var idempotentReadKeywords = []string{
+	"MATCH", "GO", "FETCH", "LOOKUP", "FIND", "GET", "SHOW", "DESCRIBE", "DESC", "YIELD", "UNWIND", "EXPLAIN", "USE",
+}
+
+/*
+`isIdempotentRead` reports whether all the statements of stmt are reads,
+it's conservative that a pipe or a semicolon in a string makes it false
+*/
+func isIdempotentRead(stmt []byte) bool {
+	for _, s := range strings.FieldsFunc(string(stmt), func(r rune) bool { return r == ';' || r == '|' }) {
+		fields := strings.Fields(s)
+		if len(fields) == 0 {
+			continue
+		}
+		read := false
+		for _, keyword := range idempotentReadKeywords {
+			if strings.EqualFold(fields[0], keyword) {
+				read = true
+				break
+			}
+		}
+		if !read {
+			return false
+		}
+	}
+	return true
+}
+
+func isTimeout(err error) bool {
+	if e, ok := err.(thrift.TransportException); ok && e.TypeID() == thrift.TIMED_OUT {
+		return true
+	}
+	if e, ok := err.(net.Error); ok && e.Timeout() {
+		return true
+	}
+	return strings.Contains(err.Error(), "i/o timeout")
+}
+
+func isConnectionError(err error) bool {
+	switch e := err.(type) {
+	case thrift.TransportException:
+		return true
+	case thrift.ProtocolException:
+		if e.TypeID() != thrift.UNKNOWN_PROTOCOL_EXCEPTION {
+			return false
+		}
+		for _, prefix := range []string{"wsasend", "wsarecv", "write:", "read:"} {
+			if strings.Contains(e.Error(), prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package nebula
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/facebook/fbthrift/thrift/lib/go/thrift"
+)
+
+func TestIsIdempotentRead(t *testing.T) {
+	cases := []struct {
+		stmt string
+		read bool
+	}{
+		{"MATCH (v) RETURN v LIMIT 10", true},
+		{"  match (v) return v", true},
+		{"GO FROM 1 OVER e YIELD dst(edge)", true},
+		{"FETCH PROP ON player 100", true},
+		{"LOOKUP ON player YIELD id(vertex)", true},
+		{"SHOW SPACES", true},
+		{"describe tag player", true},
+		{"USE nba; MATCH (v) RETURN v", true},
+		{"GO FROM 1 OVER e YIELD dst(edge) AS id | FETCH PROP ON player $-.id", true},
+		{"YIELD 1;;", true},
+		{"", true},
+		{"INSERT VERTEX player(name) VALUES 100:(\"a\")", false},
+		{"CREATE SPACE nba(vid_type=INT64)", false},
+		{"USE nba; DELETE VERTEX 100", false},
+		{"MATCH (v) RETURN v | DELETE VERTEX $-.v", false},
+		{"UPDATE VERTEX 100 SET player.age = 1", false},
+		{"DROP TAG player", false},
+		{"GET SUBGRAPH FROM 100", true},
+		{"MATCHES", false},
+		// conservative with the separators in strings
+		{"FETCH PROP ON player \"a;b\"", false},
+	}
+
+	for _, tc := range cases {
+		if read := isIdempotentRead([]byte(tc.stmt)); read != tc.read {
+			t.Errorf("%q: got %v, want %v", tc.stmt, read, tc.read)
+		}
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "read tcp: i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestIsTimeout(t *testing.T) {
+	var _ net.Error = timeoutError{}
+	cases := []struct {
+		name    string
+		err     error
+		timeout bool
+	}{
+		{"transport timed out", thrift.NewTransportException(thrift.TIMED_OUT, "timed out"), true},
+		{"transport from timeout", thrift.NewTransportExceptionFromError(timeoutError{}), true},
+		{"net error", timeoutError{}, true},
+		{"protocol i/o timeout", thrift.NewProtocolException(errors.New("read tcp 127.0.0.1:9669: i/o timeout")), true},
+		{"transport not open", thrift.NewTransportException(thrift.NOT_OPEN, "not open"), false},
+		{"transport eof", thrift.NewTransportException(thrift.END_OF_FILE, "EOF"), false},
+		{"other", errors.New("broken pipe"), false},
+	}
+
+	for _, tc := range cases {
+		if timeout := isTimeout(tc.err); timeout != tc.timeout {
+			t.Errorf("%s: got %v, want %v", tc.name, timeout, tc.timeout)
+		}
+	}
+}
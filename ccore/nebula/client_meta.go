@@ -19,6 +19,91 @@ type (
 		BalanceDataRemove(space string, endpoints []string) (types.Balancer, error)
 		ListHosts() (types.Hosts, error)
 		ListZones() (types.Zones, error)
+		ListTags(space string) (types.SchemaItems, error)
+		GetTag(space string, name string) (types.SchemaResult, error)
+		ListEdges(space string) (types.SchemaItems, error)
+		GetEdge(space string, name string) (types.SchemaResult, error)
+		ListTagIndexes(space string) (types.IndexItems, error)
+		ListEdgeIndexes(space string) (types.IndexItems, error)
+		SubmitJob(space string, cmd types.JobCmd, paras []string) (types.JobSubmitted, error)
+		ListJobs(space string) (types.Jobs, error)
+		ShowJob(space string, id int32) (types.JobDetail, error)
+		StopJob(space string, id int32) (types.MetaBaser, error)
+		RecoverJob(space string, ids []int32) (types.JobsRecovered, error)
+		CreateUser(account string, password string, ifNotExists bool) (types.MetaBaser, error)
+		DropUser(account string, ifExists bool) (types.MetaBaser, error)
+		AlterUser(account string, password string) (types.MetaBaser, error)
+		ChangePassword(account string, newPassword string, oldPassword string) (types.MetaBaser, error)
+		GrantRole(account string, space string, role types.RoleType) (types.MetaBaser, error)
+		RevokeRole(account string, space string, role types.RoleType) (types.MetaBaser, error)
+		ListUsers() (types.Users, error)
+		ListRoles(space string) (types.Roles, error)
+		GetUserRoles(account string) (types.Roles, error)
+		ListSessions() (types.Sessions, error)
+		GetSession(id int64) (types.SessionResult, error)
+		RemoveSession(id int64) (types.MetaBaser, error)
+		// KillQuery kills the queries, which are the plan ids of each session id
+		KillQuery(queries map[int64][]int64) (types.MetaBaser, error)
+		CreateSnapshot() (types.MetaBaser, error)
+		DropSnapshot(name string) (types.MetaBaser, error)
+		ListSnapshots() (types.Snapshots, error)
+		// CreateBackup backs up the spaces, or all of them if spaces is empty, it's supported since 3.0
+		CreateBackup(spaces []string) (types.BackupResult, error)
+		// RestoreMeta restores the meta files of a backup, hosts maps the storage hosts in the backup to the new ones, it's supported since 3.0
+		RestoreMeta(files []string, hosts map[string]string) (types.MetaBaser, error)
+		// ListConfigs lists the configs of module, or all the modules if it's empty
+		ListConfigs(module types.ConfigModule) (types.Configs, error)
+		// GetConfig gets the config of module, or of all the modules if it's empty
+		GetConfig(module types.ConfigModule, name string) (types.Configs, error)
+		SetConfig(module types.ConfigModule, name string, value types.Value) (types.MetaBaser, error)
+		// ListParts lists the partitions of partIDs in space, or all of them if partIDs is empty
+		ListParts(space string, partIDs []int32) (types.Parts, error)
+		// GetZone, DropZone, MergeZone, DivideZone and RenameZone are supported since 3.0
+		GetZone(zone string) (types.ZoneResult, error)
+		DropZone(zone string) (types.MetaBaser, error)
+		// MergeZone merges zones into zone, which is created if it doesn't exist
+		MergeZone(zones []string, zone string) (types.MetaBaser, error)
+		// DivideZone divides zone into the zones of zoneItems, which maps the new zones to their hosts
+		DivideZone(zone string, zoneItems map[string][]string) (types.MetaBaser, error)
+		RenameZone(zone string, newZone string) (types.MetaBaser, error)
+		CreateSpace(desc types.SpaceDesc, ifNotExists bool) (types.MetaBaser, error)
+		// CreateSpaceAs creates newSpace with the schemas of space, it's supported since 2.6
+		CreateSpaceAs(space string, newSpace string) (types.MetaBaser, error)
+		DropSpace(space string, ifExists bool) (types.MetaBaser, error)
+		GetSpace(space string) (types.SpaceResult, error)
+		// AlterSpace is supported since 3.0, paras are the zones to add for AlterSpaceAddZone
+		AlterSpace(space string, op types.AlterSpaceOp, paras []string) (types.MetaBaser, error)
+		// CreateTag creates the tag with schema, the default values of the columns are encoded as constants
+		CreateTag(space string, name string, schema types.Schema, ifNotExists bool) (types.MetaBaser, error)
+		// AlterTag alters the columns of items in order, and the ttl and the comment if prop is not nil
+		AlterTag(space string, name string, items []types.AlterSchemaItem, prop *types.SchemaProp) (types.MetaBaser, error)
+		DropTag(space string, name string, ifExists bool) (types.MetaBaser, error)
+		CreateEdge(space string, name string, schema types.Schema, ifNotExists bool) (types.MetaBaser, error)
+		AlterEdge(space string, name string, items []types.AlterSchemaItem, prop *types.SchemaProp) (types.MetaBaser, error)
+		DropEdge(space string, name string, ifExists bool) (types.MetaBaser, error)
+		CreateTagIndex(space string, desc types.IndexDesc, ifNotExists bool) (types.MetaBaser, error)
+		DropTagIndex(space string, name string, ifExists bool) (types.MetaBaser, error)
+		CreateEdgeIndex(space string, desc types.IndexDesc, ifNotExists bool) (types.MetaBaser, error)
+		DropEdgeIndex(space string, name string, ifExists bool) (types.MetaBaser, error)
+		SignInService(typ types.ServiceType, clients []types.ServiceClient) (types.MetaBaser, error)
+		SignOutService(typ types.ServiceType) (types.MetaBaser, error)
+		ListServiceClients(typ types.ServiceType) (types.ServiceClients, error)
+		// AddListener adds the listeners of space in the form of "ip:port", e.g. the ones to sync the data to elasticsearch
+		AddListener(space string, typ types.ListenerType, endpoints []string) (types.MetaBaser, error)
+		RemoveListener(space string, typ types.ListenerType) (types.MetaBaser, error)
+		ListListener(space string) (types.Listeners, error)
+		CreateFTIndex(space string, desc types.FTIndexDesc) (types.MetaBaser, error)
+		DropFTIndex(space string, name string) (types.MetaBaser, error)
+		// ListFTIndexes lists the full-text indexes of all the spaces
+		ListFTIndexes() (types.FTIndexes, error)
+		// ListTagIndexStatus lists the status of the last rebuild job of each tag index in space
+		ListTagIndexStatus(space string) (types.IndexStatuses, error)
+		ListEdgeIndexStatus(space string) (types.IndexStatuses, error)
+		// RebuildTagIndex submits the rebuild job of the tag indexes, the status of the job is polled by the returned rebuilder
+		RebuildTagIndex(space string, indexes []string) (types.IndexRebuilder, error)
+		RebuildEdgeIndex(space string, indexes []string) (types.IndexRebuilder, error)
+		// GetStats gets the result of the last STATS job of space, the job should be submitted first
+		GetStats(space string) (types.StatsResult, error)
 		Close() error
 	}
 
@@ -164,6 +249,814 @@ func (c *defaultMetaClient) BalanceDataRemove(space string, endpoints []string)
 	return
 }
 
+func (c *defaultMetaClient) ListTags(space string) (resp types.SchemaItems, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.ListTags(space)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) GetTag(space string, name string) (resp types.SchemaResult, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.GetTag(space, name)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) ListEdges(space string) (resp types.SchemaItems, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.ListEdges(space)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) GetEdge(space string, name string) (resp types.SchemaResult, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.GetEdge(space, name)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) ListTagIndexes(space string) (resp types.IndexItems, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.ListTagIndexes(space)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) ListEdgeIndexes(space string) (resp types.IndexItems, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.ListEdgeIndexes(space)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+/*
+`SubmitJob` submits the admin job cmd of space,
+paras are the index names to rebuild or the hdfs url to download, and they are empty for the other jobs
+*/
+func (c *defaultMetaClient) SubmitJob(space string, cmd types.JobCmd, paras []string) (resp types.JobSubmitted, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.SubmitJob(space, cmd, paras)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) ListJobs(space string) (resp types.Jobs, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.ListJobs(space)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) ShowJob(space string, id int32) (resp types.JobDetail, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.ShowJob(space, id)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) StopJob(space string, id int32) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.StopJob(space, id)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) RecoverJob(space string, ids []int32) (resp types.JobsRecovered, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.RecoverJob(space, ids)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) CreateUser(account string, password string, ifNotExists bool) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.CreateUser(account, password, ifNotExists)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) DropUser(account string, ifExists bool) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.DropUser(account, ifExists)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) AlterUser(account string, password string) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.AlterUser(account, password)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) ChangePassword(account string, newPassword string, oldPassword string) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.ChangePassword(account, newPassword, oldPassword)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) GrantRole(account string, space string, role types.RoleType) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.GrantRole(account, space, role)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) RevokeRole(account string, space string, role types.RoleType) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.RevokeRole(account, space, role)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) ListUsers() (resp types.Users, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.ListUsers()
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) ListRoles(space string) (resp types.Roles, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.ListRoles(space)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) GetUserRoles(account string) (resp types.Roles, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.GetUserRoles(account)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) ListSessions() (resp types.Sessions, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.ListSessions()
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) GetSession(id int64) (resp types.SessionResult, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.GetSession(id)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) RemoveSession(id int64) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.RemoveSession(id)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) KillQuery(queries map[int64][]int64) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.KillQuery(queries)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) CreateSnapshot() (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.CreateSnapshot()
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) DropSnapshot(name string) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.DropSnapshot(name)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) ListSnapshots() (resp types.Snapshots, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.ListSnapshots()
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) CreateBackup(spaces []string) (resp types.BackupResult, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.CreateBackup(spaces)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) RestoreMeta(files []string, hosts map[string]string) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.RestoreMeta(files, hosts)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) ListConfigs(module types.ConfigModule) (resp types.Configs, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.ListConfigs(module)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) GetConfig(module types.ConfigModule, name string) (resp types.Configs, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.GetConfig(module, name)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) SetConfig(module types.ConfigModule, name string, value types.Value) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.SetConfig(module, name, value)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) ListParts(space string, partIDs []int32) (resp types.Parts, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.ListParts(space, partIDs)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) GetZone(zone string) (resp types.ZoneResult, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.GetZone(zone)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) DropZone(zone string) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.DropZone(zone)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) MergeZone(zones []string, zone string) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.MergeZone(zones, zone)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) DivideZone(zone string, zoneItems map[string][]string) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.DivideZone(zone, zoneItems)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) RenameZone(zone string, newZone string) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.RenameZone(zone, newZone)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) CreateSpace(desc types.SpaceDesc, ifNotExists bool) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.CreateSpace(desc, ifNotExists)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) CreateSpaceAs(space string, newSpace string) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.CreateSpaceAs(space, newSpace)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) DropSpace(space string, ifExists bool) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.DropSpace(space, ifExists)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) GetSpace(space string) (resp types.SpaceResult, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.GetSpace(space)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) AlterSpace(space string, op types.AlterSpaceOp, paras []string) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.AlterSpace(space, op, paras)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) CreateTag(space string, name string, schema types.Schema, ifNotExists bool) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.CreateTag(space, name, schema, ifNotExists)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) AlterTag(space string, name string, items []types.AlterSchemaItem, prop *types.SchemaProp) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.AlterTag(space, name, items, prop)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) DropTag(space string, name string, ifExists bool) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.DropTag(space, name, ifExists)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) CreateEdge(space string, name string, schema types.Schema, ifNotExists bool) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.CreateEdge(space, name, schema, ifNotExists)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) AlterEdge(space string, name string, items []types.AlterSchemaItem, prop *types.SchemaProp) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.AlterEdge(space, name, items, prop)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) DropEdge(space string, name string, ifExists bool) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.DropEdge(space, name, ifExists)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) CreateTagIndex(space string, desc types.IndexDesc, ifNotExists bool) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.CreateTagIndex(space, desc, ifNotExists)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) DropTagIndex(space string, name string, ifExists bool) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.DropTagIndex(space, name, ifExists)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) CreateEdgeIndex(space string, desc types.IndexDesc, ifNotExists bool) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.CreateEdgeIndex(space, desc, ifNotExists)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) DropEdgeIndex(space string, name string, ifExists bool) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.DropEdgeIndex(space, name, ifExists)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) SignInService(typ types.ServiceType, clients []types.ServiceClient) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.SignInService(typ, clients)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) SignOutService(typ types.ServiceType) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.SignOutService(typ)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) ListServiceClients(typ types.ServiceType) (resp types.ServiceClients, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.ListServiceClients(typ)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) AddListener(space string, typ types.ListenerType, endpoints []string) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.AddListener(space, typ, endpoints)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) RemoveListener(space string, typ types.ListenerType) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.RemoveListener(space, typ)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) ListListener(space string) (resp types.Listeners, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.ListListener(space)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) CreateFTIndex(space string, desc types.FTIndexDesc) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.CreateFTIndex(space, desc)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) DropFTIndex(space string, name string) (resp types.MetaBaser, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.DropFTIndex(space, name)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) ListFTIndexes() (resp types.FTIndexes, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.ListFTIndexes()
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) ListTagIndexStatus(space string) (resp types.IndexStatuses, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.ListTagIndexStatus(space)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) ListEdgeIndexStatus(space string) (resp types.IndexStatuses, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.ListEdgeIndexStatus(space)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) RebuildTagIndex(space string, indexes []string) (resp types.IndexRebuilder, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.RebuildTagIndex(space, indexes)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) RebuildEdgeIndex(space string, indexes []string) (resp types.IndexRebuilder, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.RebuildEdgeIndex(space, indexes)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
+func (c *defaultMetaClient) GetStats(space string) (resp types.StatsResult, err error) {
+	retryErr := c.retryDo(func() (types.MetaBaser, error) {
+		resp, err = c.meta.GetStats(space)
+		return resp, err
+	})
+	if retryErr != nil {
+		return nil, retryErr
+	}
+
+	return
+}
+
 func (c *defaultMetaClient) defaultClient() *defaultClient {
 	return (*defaultClient)(c)
 }
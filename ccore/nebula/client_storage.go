@@ -0,0 +1,401 @@
+package nebula
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/facebook/fbthrift/thrift/lib/go/thrift"
+	nerrors "github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/errors"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/wrapper"
+)
+
+const (
+	DefaultScanLimit = 1000
+
+	// scanMaxRetries is the times to retry a partition after its leader is changed or the connection is broken
+	scanMaxRetries = 3
+)
+
+var (
+	// the reserved properties returned before the others
+	vertexReservedProps = []string{"_vid"}
+	edgeReservedProps   = []string{"_src", "_type", "_rank", "_dst"}
+)
+
+type (
+	// StorageClient scans the vertices and the edges from the leaders of the partitions, which are allocated by the meta client
+	StorageClient interface {
+		Open() error
+		// ScanVertex scans the vertices of tag in space, the rows start with _vid
+		ScanVertex(space string, tag string, opts ScanOptions) (*ScanIterator, error)
+		// ScanEdge scans the edges of edge in space, the rows start with _src, _type, _rank and _dst
+		ScanEdge(space string, edge string, opts ScanOptions) (*ScanIterator, error)
+		Close() error
+	}
+
+	ScanOptions struct {
+		// Props are the properties to return, all the properties of the schema are returned if it's empty
+		Props []string
+		// Parts are the partitions to scan in order, all the partitions are scanned if it's empty
+		Parts []int32
+		// Limit is the max rows of a request, it's DefaultScanLimit if it's not positive
+		Limit int64
+		// StartTime and EndTime limit the write time of the data if they are positive
+		StartTime              int64
+		EndTime                int64
+		OnlyLatestVersion      bool
+		EnableReadFromFollower bool
+		// Cursor resumes a scan from ScanIterator.Cursor, Parts is ignored if it's set
+		Cursor *ScanCursor
+	}
+
+	/*
+		`ScanCursor` is the position of a scan, Parts are the partitions not finished in order,
+		the first one is scanned from Next and its first Skip rows are skipped,
+		so the scan should be resumed with the same Limit
+	*/
+	ScanCursor struct {
+		Parts []int32 `json:"parts"`
+		Next  []byte  `json:"next"`
+		Skip  int     `json:"skip"`
+	}
+
+	// ScanRow is a vertex or an edge, Values are in the order of ScanIterator.ColumnNames
+	ScanRow struct {
+		PartID int32
+		Values []*wrapper.ValueWrapper
+	}
+
+	// ScanIterator scans the partitions one by one, and follows the leader of each partition
+	ScanIterator struct {
+		client  *defaultStorageClient
+		factory Factory
+		space   string
+		isEdge  bool
+		req     types.ScanReq
+		// leaders map all the partitions to their leaders, which are empty if the partitions have no leader
+		leaders map[int32]string
+		columns []string
+
+		parts  []int32
+		cursor []byte
+		skip   int
+
+		// fetched is true if rows are the ones scanned from cursor of the first partition
+		fetched    bool
+		rows       []ScanRow
+		pos        int
+		nextCursor []byte
+
+		row ScanRow
+		err error
+	}
+
+	defaultStorageClient defaultClient
+)
+
+func NewStorageClient(metaEndpoints []string, opts ...Option) (StorageClient, error) {
+	c, err := NewClient(ConnectionInfo{
+		MetaEndpoints: metaEndpoints,
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return c.Storage(), nil
+}
+
+// Open opens the meta client, the storage hosts are connected when they are scanned
+func (c *defaultStorageClient) Open() error {
+	return c.metaClient().Open()
+}
+
+func (c *defaultStorageClient) ScanVertex(space string, tag string, opts ScanOptions) (*ScanIterator, error) {
+	return c.scan(space, tag, false, opts)
+}
+
+func (c *defaultStorageClient) ScanEdge(space string, edge string, opts ScanOptions) (*ScanIterator, error) {
+	return c.scan(space, edge, true, opts)
+}
+
+func (c *defaultStorageClient) Close() error {
+	if err := c.storage.close(); err != nil {
+		return err
+	}
+	return c.meta.close()
+}
+
+func (c *defaultStorageClient) scan(space string, name string, isEdge bool, opts ScanOptions) (*ScanIterator, error) {
+	spaceResp, err := c.metaClient().GetSpace(space)
+	if err != nil {
+		return nil, err
+	}
+	if spaceResp.GetCode() != nerrors.ErrorCode_SUCCEEDED {
+		return nil, nerrors.NewCodeError(spaceResp.GetCode(), "failed to get space "+space)
+	}
+	schema, err := c.getSchema(space, name, isEdge)
+	if err != nil {
+		return nil, err
+	}
+
+	props := vertexReservedProps
+	if isEdge {
+		props = edgeReservedProps
+	}
+	props = append(append([]string{}, props...), opts.Props...)
+	if len(opts.Props) == 0 {
+		for _, column := range schema.Schema.Columns {
+			props = append(props, column.Name)
+		}
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultScanLimit
+	}
+
+	it := &ScanIterator{
+		client:  c,
+		factory: c.defaultClient().Factory(),
+		space:   space,
+		isEdge:  isEdge,
+		req: types.ScanReq{
+			SpaceID:                spaceResp.GetSpace().ID,
+			SchemaID:               schema.ID,
+			Props:                  props,
+			Limit:                  limit,
+			StartTime:              opts.StartTime,
+			EndTime:                opts.EndTime,
+			OnlyLatestVersion:      opts.OnlyLatestVersion,
+			EnableReadFromFollower: opts.EnableReadFromFollower,
+		},
+	}
+	if err = it.loadLeaders(); err != nil {
+		return nil, err
+	}
+	switch {
+	case opts.Cursor != nil:
+		it.parts = append([]int32{}, opts.Cursor.Parts...)
+		it.cursor = opts.Cursor.Next
+		it.skip = opts.Cursor.Skip
+	case len(opts.Parts) > 0:
+		it.parts = append([]int32{}, opts.Parts...)
+	default:
+		for partID := range it.leaders {
+			it.parts = append(it.parts, partID)
+		}
+		sort.Slice(it.parts, func(i, j int) bool {
+			return it.parts[i] < it.parts[j]
+		})
+	}
+	return it, nil
+}
+
+// getSchema gets the latest version of the tag or the edge type name
+func (c *defaultStorageClient) getSchema(space string, name string, isEdge bool) (*types.SchemaItem, error) {
+	var (
+		resp types.SchemaItems
+		err  error
+	)
+	if isEdge {
+		resp, err = c.metaClient().ListEdges(space)
+	} else {
+		resp, err = c.metaClient().ListTags(space)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetCode() != nerrors.ErrorCode_SUCCEEDED {
+		return nil, nerrors.NewCodeError(resp.GetCode(), "failed to list the schemas of "+space)
+	}
+
+	var schema *types.SchemaItem
+	for _, item := range resp.GetItems() {
+		if item.Name != name {
+			continue
+		}
+		if schema == nil || item.Version > schema.Version {
+			item := item
+			schema = &item
+		}
+	}
+	if schema == nil {
+		if isEdge {
+			return nil, nerrors.NewCodeError(nerrors.ErrorCode_E_EDGE_NOT_FOUND, "edge "+name+" not found")
+		}
+		return nil, nerrors.NewCodeError(nerrors.ErrorCode_E_TAG_NOT_FOUND, "tag "+name+" not found")
+	}
+	return schema, nil
+}
+
+func (c *defaultStorageClient) metaClient() *defaultMetaClient {
+	return (*defaultMetaClient)(c)
+}
+
+// Next scans the next row, it returns false when all the partitions are scanned or an error occurs
+func (it *ScanIterator) Next() bool {
+	for it.err == nil {
+		if it.fetched && it.pos < len(it.rows) {
+			it.row = it.rows[it.pos]
+			it.pos++
+			return true
+		}
+		if it.fetched {
+			it.advance()
+		}
+		if len(it.parts) == 0 {
+			return false
+		}
+		it.err = it.fetch()
+	}
+	return false
+}
+
+// Row is the row scanned by the last Next
+func (it *ScanIterator) Row() ScanRow {
+	return it.row
+}
+
+// Err is the error which stops Next
+func (it *ScanIterator) Err() error {
+	return it.err
+}
+
+// ColumnNames are the names of the values of the rows, they are set after the first row is scanned
+func (it *ScanIterator) ColumnNames() []string {
+	return it.columns
+}
+
+// Cursor is the position after the row scanned by the last Next
+func (it *ScanIterator) Cursor() ScanCursor {
+	if it.fetched && it.pos == len(it.rows) {
+		it.advance()
+	}
+	cursor := ScanCursor{
+		Parts: append([]int32{}, it.parts...),
+		Next:  it.cursor,
+		Skip:  it.skip,
+	}
+	if it.fetched {
+		cursor.Skip = it.pos
+	}
+	return cursor
+}
+
+// advance moves the cursor after the rows fetched, to the next partition if the first one is scanned to the end
+func (it *ScanIterator) advance() {
+	if it.nextCursor != nil {
+		it.cursor = it.nextCursor
+	} else {
+		it.parts = it.parts[1:]
+		it.cursor = nil
+	}
+	it.fetched = false
+	it.rows = nil
+	it.pos = 0
+	it.nextCursor = nil
+}
+
+// fetch scans the first partition from cursor on its leader, and retries after the leader is changed or down
+func (it *ScanIterator) fetch() error {
+	partID := it.parts[0]
+	req := it.req
+	req.PartID = partID
+	req.Cursor = it.cursor
+
+	for retry := 0; ; retry++ {
+		leader := it.leaders[partID]
+		if leader == "" {
+			return nerrors.NewCodeError(nerrors.ErrorCode_E_LEADER_CHANGED, fmt.Sprintf("part %d has no leader", partID))
+		}
+		var resp types.ScanResult
+		err := it.client.storage.do(it.client.driver, leader, func(client types.GraphStorageClientDriver) (err error) {
+			if it.isEdge {
+				resp, err = client.ScanEdge(req)
+			} else {
+				resp, err = client.ScanVertex(req)
+			}
+			return err
+		})
+		if err != nil {
+			// the leader may be down, either failing to connect or breaking the connection
+			if _, ok := err.(thrift.TransportException); ok && retry < scanMaxRetries {
+				if err = it.loadLeaders(); err != nil {
+					return err
+				}
+				continue
+			}
+			return err
+		}
+
+		if failedParts := resp.GetFailedParts(); len(failedParts) > 0 {
+			failed := failedParts[0]
+			if failed.Code == nerrors.ErrorCode_E_LEADER_CHANGED && retry < scanMaxRetries {
+				if failed.Leader != nil {
+					it.leaders[partID] = fmt.Sprintf("%s:%d", failed.Leader.Host, failed.Leader.Port)
+				} else if err = it.loadLeaders(); err != nil {
+					return err
+				}
+				continue
+			}
+			return nerrors.NewCodeError(failed.Code, fmt.Sprintf("failed to scan part %d of %s", partID, it.space))
+		}
+
+		return it.setRows(partID, resp)
+	}
+}
+
+func (it *ScanIterator) setRows(partID int32, resp types.ScanResult) error {
+	it.fetched = true
+	it.rows = nil
+	it.pos = it.skip
+	it.skip = 0
+	it.nextCursor = resp.GetNextCursor()
+
+	data := resp.GetData()
+	if data == nil {
+		return nil
+	}
+	if it.columns == nil {
+		for _, name := range data.GetColumnNames() {
+			it.columns = append(it.columns, string(name))
+		}
+	}
+	it.rows = make([]ScanRow, 0, len(data.GetRows()))
+	for _, row := range data.GetRows() {
+		values, err := wrapper.GenValWraps(row, it.factory, types.TimezoneInfo{})
+		if err != nil {
+			return err
+		}
+		it.rows = append(it.rows, ScanRow{
+			PartID: partID,
+			Values: values,
+		})
+	}
+	if it.pos > len(it.rows) {
+		it.pos = len(it.rows)
+	}
+	return nil
+}
+
+// loadLeaders gets the partitions and their leaders from the meta client
+func (it *ScanIterator) loadLeaders() error {
+	resp, err := it.client.metaClient().ListParts(it.space, nil)
+	if err != nil {
+		return err
+	}
+	if resp.GetCode() != nerrors.ErrorCode_SUCCEEDED {
+		return nerrors.NewCodeError(resp.GetCode(), "failed to list parts of "+it.space)
+	}
+	it.leaders = make(map[int32]string, len(resp.GetParts()))
+	for _, part := range resp.GetParts() {
+		it.leaders[part.ID] = ""
+		if part.Leader != nil {
+			it.leaders[part.ID] = fmt.Sprintf("%s:%d", part.Leader.Host, part.Leader.Port)
+		}
+	}
+	return nil
+}
+
+func (c *defaultStorageClient) defaultClient() *defaultClient {
+	return (*defaultClient)(c)
+}
@@ -3,8 +3,25 @@ package nebula
 import "github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
 
 type (
+	// StorageAdminClient sends the admin requests to a storage host, the hosts are in the form of "ip:port"
 	StorageAdminClient interface {
 		Open() error
+		// TransLeader transfers the leader of the partition on the host to newLeader
+		TransLeader(spaceID int32, partID int32, newLeader string) (types.StorageAdminResult, error)
+		// AddPart adds the partition with peers on the host, as a learner if asLearner is true
+		AddPart(spaceID int32, partID int32, asLearner bool, peers []string) (types.StorageAdminResult, error)
+		RemovePart(spaceID int32, partID int32) (types.StorageAdminResult, error)
+		// MemberChange adds or removes the peer of the partition, it should be sent to the leader
+		MemberChange(spaceID int32, partID int32, peer string, add bool) (types.StorageAdminResult, error)
+		// CreateCheckpoint creates the checkpoint name of the spaces, a request is sent for each space before 3.0
+		CreateCheckpoint(spaceIDs []int32, name string) (types.CheckpointResult, error)
+		DropCheckpoint(spaceIDs []int32, name string) (types.StorageAdminResult, error)
+		// BlockingWrites blocks or unblocks the writes of the spaces
+		BlockingWrites(spaceIDs []int32, sign types.EngineSignType) (types.StorageAdminResult, error)
+		// GetLeaderParts gets the partitions led by the host of each space
+		GetLeaderParts() (types.LeaderParts, error)
+		// CheckPeers checks the peers of the partition on the host, and updates them if they differ
+		CheckPeers(spaceID int32, partID int32, peers []string) (types.StorageAdminResult, error)
 		Close() error
 	}
 
@@ -27,6 +44,42 @@ func (c *defaultStorageAdminClient) Open() error {
 	})
 }
 
+func (c *defaultStorageAdminClient) TransLeader(spaceID int32, partID int32, newLeader string) (types.StorageAdminResult, error) {
+	return c.storageAdmin.TransLeader(spaceID, partID, newLeader)
+}
+
+func (c *defaultStorageAdminClient) AddPart(spaceID int32, partID int32, asLearner bool, peers []string) (types.StorageAdminResult, error) {
+	return c.storageAdmin.AddPart(spaceID, partID, asLearner, peers)
+}
+
+func (c *defaultStorageAdminClient) RemovePart(spaceID int32, partID int32) (types.StorageAdminResult, error) {
+	return c.storageAdmin.RemovePart(spaceID, partID)
+}
+
+func (c *defaultStorageAdminClient) MemberChange(spaceID int32, partID int32, peer string, add bool) (types.StorageAdminResult, error) {
+	return c.storageAdmin.MemberChange(spaceID, partID, peer, add)
+}
+
+func (c *defaultStorageAdminClient) CreateCheckpoint(spaceIDs []int32, name string) (types.CheckpointResult, error) {
+	return c.storageAdmin.CreateCheckpoint(spaceIDs, name)
+}
+
+func (c *defaultStorageAdminClient) DropCheckpoint(spaceIDs []int32, name string) (types.StorageAdminResult, error) {
+	return c.storageAdmin.DropCheckpoint(spaceIDs, name)
+}
+
+func (c *defaultStorageAdminClient) BlockingWrites(spaceIDs []int32, sign types.EngineSignType) (types.StorageAdminResult, error) {
+	return c.storageAdmin.BlockingWrites(spaceIDs, sign)
+}
+
+func (c *defaultStorageAdminClient) GetLeaderParts() (types.LeaderParts, error) {
+	return c.storageAdmin.GetLeaderParts()
+}
+
+func (c *defaultStorageAdminClient) CheckPeers(spaceID int32, partID int32, peers []string) (types.StorageAdminResult, error) {
+	return c.storageAdmin.CheckPeers(spaceID, partID, peers)
+}
+
 func (c *defaultStorageAdminClient) Close() error {
 	return c.storageAdmin.close()
 }
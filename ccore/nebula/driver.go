@@ -1,6 +1,7 @@
 package nebula
 
 import (
+	"strings"
 	"sync"
 
 	"github.com/facebook/fbthrift/thrift/lib/go/thrift"
@@ -17,8 +18,12 @@ type (
 		connection *connectionMu
 		username   string
 		password   string
+		// passwordMu guards password, which can be changed while reconnecting
+		passwordMu sync.Mutex
 		sessionId  int64
 		timezone   types.TimezoneInfo
+		// space is the space in use, it's used again after reconnecting
+		space []byte
 	}
 
 	driverMeta struct {
@@ -31,6 +36,19 @@ type (
 		connection *connectionMu
 	}
 
+	// driverStorage keeps a connection to each storage host, the hosts are the leaders of the partitions to scan
+	driverStorage struct {
+		o     *socketOptions
+		mu    sync.Mutex
+		conns map[string]*storageConn
+	}
+
+	// storageConn is the connection to a storage host, mu is held by the request using it
+	storageConn struct {
+		types.GraphStorageClientDriver
+		mu sync.Mutex
+	}
+
 	connectionMu struct {
 		o         *socketOptions
 		mu        sync.Mutex
@@ -63,6 +81,13 @@ func newDriverStorageAdmin(endpoints []string, o *socketOptions) *driverStorageA
 	}
 }
 
+func newDriverStorage(o *socketOptions) *driverStorage {
+	return &driverStorage{
+		o:     o,
+		conns: make(map[string]*storageConn),
+	}
+}
+
 func newConnectionMu(endpoints []string, o *socketOptions) *connectionMu {
 	return &connectionMu{
 		o:         o,
@@ -95,7 +120,10 @@ func (d *driverGraph) open(driver types.Driver) error {
 		return err
 	}
 
-	resp, err := graphClientDriver.Authenticate(d.username, d.password)
+	d.passwordMu.Lock()
+	password := d.password
+	d.passwordMu.Unlock()
+	resp, err := graphClientDriver.Authenticate(d.username, password)
 	if err != nil {
 		_ = graphClientDriver.Close()
 		return err
@@ -125,6 +153,41 @@ func (d *driverGraph) close() error {
 	return nil
 }
 
+func (d *driverGraph) setPassword(password string) {
+	d.passwordMu.Lock()
+	d.password = password
+	d.passwordMu.Unlock()
+}
+
+// reset drops the broken connection without signing out, so the next open connects again
+func (d *driverGraph) reset() {
+	if d.GraphClientDriver != nil {
+		_ = d.GraphClientDriver.Close()
+		d.GraphClientDriver = nil
+	}
+}
+
+func (d *driverGraph) updateSpace(resp types.ExecutionResponse) {
+	if resp.IsSetSpaceName() && len(resp.GetSpaceName()) > 0 {
+		d.space = resp.GetSpaceName()
+	}
+}
+
+func (d *driverGraph) restoreSpace() error {
+	if len(d.space) == 0 {
+		return nil
+	}
+	stmt := "USE `" + strings.ReplaceAll(string(d.space), "`", "\\`") + "`"
+	resp, err := d.Execute(d.sessionId, []byte(stmt))
+	if err != nil {
+		return err
+	}
+	if resp.GetErrorCode() != nerrors.ErrorCode_SUCCEEDED {
+		return nerrors.NewCodeError(resp.GetErrorCode(), string(resp.GetErrorMsg()))
+	}
+	return nil
+}
+
 func (d *driverMeta) open(driver types.Driver) error {
 	transport, pf, err := d.connection.connect()
 	if err != nil {
@@ -184,6 +247,75 @@ func (d *driverStorageAdmin) close() error {
 	return nil
 }
 
+/*
+`do` runs fn with the connection to endpoint, the calls to the same endpoint are serialized
+since a connection can't be shared by the concurrent requests.
+The connection is dropped after a transport error, and the connect errors are transport errors too,
+so the next call connects again.
+*/
+func (d *driverStorage) do(driver types.Driver, endpoint string, fn func(client types.GraphStorageClientDriver) error) error {
+	d.mu.Lock()
+	conn, ok := d.conns[endpoint]
+	if !ok {
+		conn = &storageConn{}
+		d.conns[endpoint] = conn
+	}
+	d.mu.Unlock()
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	if conn.GraphStorageClientDriver == nil {
+		if err := conn.open(driver, endpoint, d.o); err != nil {
+			if _, ok := err.(thrift.TransportException); !ok {
+				err = thrift.NewTransportExceptionFromError(err)
+			}
+			return err
+		}
+	}
+
+	err := fn(conn.GraphStorageClientDriver)
+	if _, ok := err.(thrift.TransportException); ok {
+		_ = conn.GraphStorageClientDriver.Close()
+		conn.GraphStorageClientDriver = nil
+	}
+	return err
+}
+
+func (d *driverStorage) close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var err error
+	for endpoint, conn := range d.conns {
+		conn.mu.Lock()
+		if conn.GraphStorageClientDriver != nil {
+			if e := conn.GraphStorageClientDriver.Close(); e != nil && err == nil {
+				err = e
+			}
+			conn.GraphStorageClientDriver = nil
+		}
+		conn.mu.Unlock()
+		delete(d.conns, endpoint)
+	}
+	return err
+}
+
+func (c *storageConn) open(driver types.Driver, endpoint string, o *socketOptions) error {
+	transport, pf, err := newConnectionMu([]string{endpoint}, o).connect()
+	if err != nil {
+		return err
+	}
+
+	graphStorageClientDriver := driver.NewGraphStorageClientDriver(transport, pf)
+
+	if err = graphStorageClientDriver.Open(); err != nil {
+		_ = transport.Close()
+		return err
+	}
+
+	c.GraphStorageClientDriver = graphStorageClientDriver
+	return nil
+}
+
 func (c *connectionMu) connect() (thrift.Transport, thrift.ProtocolFactory, error) {
 	if len(c.endpoints) == 0 {
 		return nil, nil, nerrors.ErrNoEndpoints
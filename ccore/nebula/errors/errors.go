@@ -3,10 +3,16 @@ package errors
 import "errors"
 
 var (
-	ErrUnsupportedVersion  = errors.New("unsupported version")
-	ErrUnsupported         = errors.New("unsupported")
-	ErrNoEndpoints         = errors.New("no endpoints")
-	ErrNoJobStats          = errors.New("no job stats")
-	ErrUnknownMetaEndpoint = errors.New("unknown meta endpoint to update connection")
-	ErrNoValidMetaEndpoint = errors.New("no valid meta endpoint to connect")
+	ErrUnsupportedVersion   = errors.New("unsupported version")
+	ErrUnsupported          = errors.New("unsupported")
+	ErrNoEndpoints          = errors.New("no endpoints")
+	ErrNoJobStats           = errors.New("no job stats")
+	ErrUnknownMetaEndpoint  = errors.New("unknown meta endpoint to update connection")
+	ErrNoValidMetaEndpoint  = errors.New("no valid meta endpoint to connect")
+	ErrNoValidGraphEndpoint = errors.New("no valid graph endpoint to connect")
+	ErrUnknownRoleType      = errors.New("unknown role type")
+	ErrUnknownConfigModule  = errors.New("unknown config module")
+	ErrUnknownPropertyType  = errors.New("unknown property type")
+	ErrUnknownEngineSign    = errors.New("unknown engine sign")
+	ErrStatementNotRetried  = errors.New("the connection was broken and reconnected, the statement may or may not have been executed")
 )
@@ -0,0 +1,277 @@
+package dao
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/gateway/pool"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
+)
+
+var UnknownBalanceCmdError = errors.New("unknown balance command, it should be data, leader or dataRemove")
+
+type Space struct {
+	ID   int32  `json:"id"`
+	Name string `json:"name"`
+}
+
+type Host struct {
+	Host   string `json:"host"`
+	Port   int32  `json:"port"`
+	Status string `json:"status"`
+	// LeaderParts and AllParts are the partitions of each space on the host
+	LeaderParts map[string][]int32 `json:"leaderParts"`
+	AllParts    map[string][]int32 `json:"allParts"`
+	Role        int64              `json:"role"`
+	GitInfoSha  string             `json:"gitInfoSha"`
+	ZoneName    string             `json:"zoneName"`
+	Version     string             `json:"version"`
+}
+
+type Zone struct {
+	Name  string   `json:"name"`
+	Hosts []string `json:"hosts"`
+}
+
+type BalanceResult struct {
+	// JobID is the balance job to track with ShowJob, it's 0 before 3.0
+	JobID int32              `json:"jobId"`
+	Stats types.BalanceStats `json:"stats"`
+}
+
+var hostStatusNames = map[types.HostStatus]string{
+	0: "ONLINE",
+	1: "OFFLINE",
+	2: "UNKNOWN",
+}
+
+func ListSpaces(nsid string) ([]Space, error) {
+	spaces := make([]Space, 0)
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.ListSpaces()
+		if err != nil {
+			return err
+		}
+		if err := metaCodeError(resp, "list spaces"); err != nil {
+			return err
+		}
+		for _, space := range resp.GetSpaces() {
+			spaces = append(spaces, Space{
+				ID:   space.GetId(),
+				Name: space.GetName(),
+			})
+		}
+		return nil
+	})
+	return spaces, err
+}
+
+func ListHosts(nsid string) ([]Host, error) {
+	hosts := make([]Host, 0)
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.ListHosts()
+		if err != nil {
+			return err
+		}
+		if err := metaCodeError(resp, "list hosts"); err != nil {
+			return err
+		}
+		for _, host := range resp.GetHosts() {
+			item := host.GetHostItem()
+			status, ok := hostStatusNames[item.Status]
+			if !ok {
+				status = fmt.Sprintf("%d", item.Status)
+			}
+			hosts = append(hosts, Host{
+				Host:        item.HostAddr.Host,
+				Port:        item.HostAddr.Port,
+				Status:      status,
+				LeaderParts: item.LeaderParts,
+				AllParts:    item.AllParts,
+				Role:        item.Role,
+				GitInfoSha:  string(item.GitInfoSha),
+				ZoneName:    string(item.ZoneName),
+				Version:     string(item.Version),
+			})
+		}
+		return nil
+	})
+	return hosts, err
+}
+
+func ListZones(nsid string) ([]Zone, error) {
+	zones := make([]Zone, 0)
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.ListZones()
+		if err != nil {
+			return err
+		}
+		if err := metaCodeError(resp, "list zones"); err != nil {
+			return err
+		}
+		for _, zone := range resp.GetZones() {
+			hosts := make([]string, 0, len(zone.GetHosts()))
+			for _, host := range zone.GetHosts() {
+				hosts = append(hosts, fmt.Sprintf("%s:%d", host.Host, host.Port))
+			}
+			zones = append(zones, Zone{
+				Name:  zone.GetName(),
+				Hosts: hosts,
+			})
+		}
+		return nil
+	})
+	return zones, err
+}
+
+// GetZone gets the zone and its hosts, it's supported since 3.0
+func GetZone(nsid string, zone string) (*Zone, error) {
+	var result Zone
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.GetZone(zone)
+		if err != nil {
+			return err
+		}
+		if err := metaCodeError(resp, "get zone "+zone); err != nil {
+			return err
+		}
+		hosts := make([]string, 0, len(resp.GetHosts()))
+		for _, host := range resp.GetHosts() {
+			hosts = append(hosts, fmt.Sprintf("%s:%d", host.Host, host.Port))
+		}
+		result = Zone{
+			Name:  resp.GetName(),
+			Hosts: hosts,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DropZone drops the zone, the hosts in it should be dropped first, it's supported since 3.0
+func DropZone(nsid string, zone string) error {
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.DropZone(zone)
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "drop zone "+zone)
+	})
+}
+
+// MergeZone merges zones into zone, which is created if it doesn't exist, it's supported since 3.0
+func MergeZone(nsid string, zones []string, zone string) error {
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.MergeZone(zones, zone)
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "merge zones into "+zone)
+	})
+}
+
+/*
+`DivideZone` divides zone into the new zones, zoneItems maps each new zone to its hosts in the form of "ip:port",
+all the hosts of zone should be divided, it's supported since 3.0
+*/
+func DivideZone(nsid string, zone string, zoneItems map[string][]string) error {
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.DivideZone(zone, zoneItems)
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "divide zone "+zone)
+	})
+}
+
+// RenameZone renames zone to newZone, it's supported since 3.0
+func RenameZone(nsid string, zone string, newZone string) error {
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.RenameZone(zone, newZone)
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "rename zone "+zone)
+	})
+}
+
+/*
+`AddHosts` adds the storage hosts in the form of "ip:port",
+they are added into zone if it's not empty, and isNew creates the zone
+*/
+func AddHosts(nsid string, hosts []string, zone string, isNew bool) error {
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		var (
+			resp types.MetaBaser
+			err  error
+		)
+		if zone == "" {
+			resp, err = metaClient.AddHosts(hosts)
+		} else {
+			resp, err = metaClient.AddHostsIntoZone(zone, hosts, isNew)
+		}
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "add hosts")
+	})
+}
+
+func DropHosts(nsid string, hosts []string) error {
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.DropHosts(hosts)
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "drop hosts")
+	})
+}
+
+/*
+`Balance` submits the balance job of space, cmd is one of data, leader and dataRemove,
+hostsToRemove are the hosts to move the data out for dataRemove
+*/
+func Balance(nsid string, cmd string, space string, hostsToRemove []string) (*BalanceResult, error) {
+	var result BalanceResult
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		var (
+			balancer types.Balancer
+			err      error
+		)
+		switch cmd {
+		case "data":
+			balancer, err = metaClient.BalanceData(space)
+		case "leader":
+			balancer, err = metaClient.BalanceLeader(space)
+		case "dataRemove":
+			balancer, err = metaClient.BalanceDataRemove(space, hostsToRemove)
+		default:
+			return UnknownBalanceCmdError
+		}
+		if err != nil {
+			return err
+		}
+		if err := metaCodeError(balancer, "balance "+cmd); err != nil {
+			return err
+		}
+		result.JobID = balancer.GetJobID()
+		result.Stats, err = balancer.GetStats()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func adminDo(nsid string, fn func(metaClient nebula.MetaClient) error) error {
+	client, err := pool.GetClient(nsid)
+	if err != nil {
+		return err
+	}
+	return client.AdminDo(fn)
+}
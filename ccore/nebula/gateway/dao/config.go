@@ -0,0 +1,219 @@
+package dao
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/gateway/pool"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/wrapper"
+)
+
+const (
+	ConfigDiffChanged = "changed"
+	ConfigDiffMissing = "missing"
+)
+
+type Config struct {
+	Module string    `json:"module"`
+	Name   string    `json:"name"`
+	Mode   string    `json:"mode"`
+	Value  types.Any `json:"value"`
+}
+
+/*
+`ConfigDiff` is a desired config which differs from the current one,
+Status is changed, or missing if the config is not in the module
+*/
+type ConfigDiff struct {
+	Module  string    `json:"module"`
+	Name    string    `json:"name"`
+	Mode    string    `json:"mode"`
+	Current types.Any `json:"current"`
+	Desired types.Any `json:"desired"`
+	Status  string    `json:"status"`
+}
+
+// ListConfigs lists the configs of module, e.g. storage, or all the modules if it's empty
+func ListConfigs(nsid string, module string) ([]Config, error) {
+	return configDo(nsid, "list configs", func(metaClient nebula.MetaClient) (types.Configs, error) {
+		return metaClient.ListConfigs(types.ConfigModule(strings.ToUpper(module)))
+	})
+}
+
+func GetConfig(nsid string, module string, name string) ([]Config, error) {
+	return configDo(nsid, "get config "+name, func(metaClient nebula.MetaClient) (types.Configs, error) {
+		return metaClient.GetConfig(types.ConfigModule(strings.ToUpper(module)), name)
+	})
+}
+
+// SetConfig sets the mutable config, value is a bool, number, string or a map of them
+func SetConfig(nsid string, module string, name string, value interface{}) error {
+	client, err := pool.GetClient(nsid)
+	if err != nil {
+		return err
+	}
+	nValue, err := wrapper.WrapValue(value, client.Factory())
+	if err != nil {
+		return err
+	}
+	return client.AdminDo(func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.SetConfig(types.ConfigModule(strings.ToUpper(module)), name, nValue)
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "set config "+name)
+	})
+}
+
+/*
+`DiffConfigs` compares the desired configs with the current ones,
+desired maps the modules to the configs, e.g. {"storage": {"wal_ttl": 14400}},
+and the configs equal to the current ones are not returned
+*/
+func DiffConfigs(nsid string, desired map[string]map[string]interface{}) ([]ConfigDiff, error) {
+	client, err := pool.GetClient(nsid)
+	if err != nil {
+		return nil, err
+	}
+
+	modules := make([]string, 0, len(desired))
+	for module := range desired {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+
+	diffs := make([]ConfigDiff, 0)
+	for _, module := range modules {
+		configs, err := ListConfigs(nsid, module)
+		if err != nil {
+			return nil, err
+		}
+		current := make(map[string]Config, len(configs))
+		for _, config := range configs {
+			current[config.Name] = config
+		}
+
+		names := make([]string, 0, len(desired[module]))
+		for name := range desired[module] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			// normalize the desired value as it's read from meta, e.g. 1.0 is 1
+			nValue, err := wrapper.WrapValue(desired[module][name], client.Factory())
+			if err != nil {
+				return nil, err
+			}
+			desiredValue, err := getConfigValue(client, nValue)
+			if err != nil {
+				return nil, err
+			}
+
+			config, ok := current[name]
+			if !ok {
+				diffs = append(diffs, ConfigDiff{
+					Module:  strings.ToUpper(module),
+					Name:    name,
+					Desired: desiredValue,
+					Status:  ConfigDiffMissing,
+				})
+				continue
+			}
+			if reflect.DeepEqual(config.Value, desiredValue) {
+				continue
+			}
+			diffs = append(diffs, ConfigDiff{
+				Module:  config.Module,
+				Name:    name,
+				Mode:    config.Mode,
+				Current: config.Value,
+				Desired: desiredValue,
+				Status:  ConfigDiffChanged,
+			})
+		}
+	}
+	return diffs, nil
+}
+
+func configDo(nsid string, action string, get func(metaClient nebula.MetaClient) (types.Configs, error)) ([]Config, error) {
+	client, err := pool.GetClient(nsid)
+	if err != nil {
+		return nil, err
+	}
+
+	configs := make([]Config, 0)
+	err = client.AdminDo(func(metaClient nebula.MetaClient) error {
+		resp, err := get(metaClient)
+		if err != nil {
+			return err
+		}
+		if err := metaCodeError(resp, action); err != nil {
+			return err
+		}
+		for _, item := range resp.GetConfigs() {
+			config := Config{
+				Module: string(item.Module),
+				Name:   item.Name,
+				Mode:   string(item.Mode),
+			}
+			if item.Value != nil {
+				if config.Value, err = getConfigValue(client, item.Value); err != nil {
+					return err
+				}
+			}
+			configs = append(configs, config)
+		}
+		return nil
+	})
+	return configs, err
+}
+
+// getConfigValue converts the value into json, the maps and lists are converted recursively
+func getConfigValue(client *pool.Client, value types.Value) (types.Any, error) {
+	return convertConfigValue(wrapper.NewValueWrapper(value, client.Factory(), client.TimezoneInfo()))
+}
+
+func convertConfigValue(valWrap *wrapper.ValueWrapper) (types.Any, error) {
+	switch valWrap.GetType() {
+	case "map":
+		valueMap, err := valWrap.AsMap()
+		if err != nil {
+			return nil, err
+		}
+		m := make(map[string]types.Any, len(valueMap))
+		for k, v := range valueMap {
+			v := v
+			if m[k], err = convertConfigValue(&v); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	case "list", "set":
+		var (
+			valueList []wrapper.ValueWrapper
+			err       error
+		)
+		if valWrap.GetType() == "list" {
+			valueList, err = valWrap.AsList()
+		} else {
+			valueList, err = valWrap.AsDedupList()
+		}
+		if err != nil {
+			return nil, err
+		}
+		l := make([]types.Any, 0, len(valueList))
+		for i := range valueList {
+			v, err := convertConfigValue(&valueList[i])
+			if err != nil {
+				return nil, err
+			}
+			l = append(l, v)
+		}
+		return l, nil
+	default:
+		return getBasicValue(valWrap)
+	}
+}
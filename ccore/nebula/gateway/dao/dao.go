@@ -2,6 +2,9 @@ package dao
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula"
 	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/gateway/pool"
@@ -16,6 +19,38 @@ type ExecuteResult struct {
 	LocalParams types.ParameterMap     `json:"localParams"`
 }
 
+type BatchResult struct {
+	Result ExecuteResult
+	Msg    interface{}
+	Error  error
+}
+
+type StreamResult struct {
+	TimeCost    int64              `json:"timeCost"`
+	LocalParams types.ParameterMap `json:"localParams"`
+}
+
+// ResultWriter receives the headers and then each converted row of an execution.
+type ResultWriter interface {
+	WriteHeaders(headers []string) error
+	WriteRow(row map[string]types.Any) error
+}
+
+// tableWriter collects the rows into an ExecuteResult.
+type tableWriter struct {
+	result *ExecuteResult
+}
+
+func (w *tableWriter) WriteHeaders(headers []string) error {
+	w.result.Headers = headers
+	return nil
+}
+
+func (w *tableWriter) WriteRow(row map[string]types.Any) error {
+	w.result.Tables = append(w.result.Tables, row)
+	return nil
+}
+
 type list []types.Any
 
 func getID(idWarp wrapper.ValueWrapper) types.Any {
@@ -291,6 +326,14 @@ func Connect(address string, port int, username string, password string, opts ..
 	return info, nil
 }
 
+func ConnectEndpoints(endpoints []string, metaEndpoints []string, username string, password string, opts ...nebula.Option) (*pool.ClientInfo, error) {
+	info, err := pool.NewClientWithEndpoints(endpoints, metaEndpoints, username, password, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
 func Disconnect(nsid string) error {
 	client, err := pool.GetClient(nsid)
 	if err != nil {
@@ -311,36 +354,146 @@ func Execute(nsid string, gql string, paramList types.ParameterList) (ExecuteRes
 		Tables:      make([]map[string]types.Any, 0),
 		LocalParams: nil,
 	}
+	localParams, timeCost, msg, err := execute(nsid, gql, paramList, 0, &tableWriter{result: &result})
+	result.LocalParams = localParams
+	result.TimeCost = timeCost
+	return result, msg, err
+}
+
+/*
+executes the gql based on nsid like `Execute`,
+but gives up waiting after timeout and kills the running query,
+a timeout <= 0 means no timeout.
+*/
+func ExecuteWithTimeout(nsid string, gql string, paramList types.ParameterList, timeout time.Duration) (ExecuteResult, interface{}, error) {
+	result := ExecuteResult{
+		Headers:     make([]string, 0),
+		Tables:      make([]map[string]types.Any, 0),
+		LocalParams: nil,
+	}
+	localParams, timeCost, msg, err := execute(nsid, gql, paramList, timeout, &tableWriter{result: &result})
+	result.LocalParams = localParams
+	result.TimeCost = timeCost
+	return result, msg, err
+}
+
+/*
+executes the gql based on nsid like `Execute`, but hands the headers and
+every converted row to w as soon as it is ready instead of collecting them,
+so the caller can flush large results without holding them in memory.
+*/
+func ExecuteStream(nsid string, gql string, paramList types.ParameterList, timeout time.Duration, w ResultWriter) (StreamResult, interface{}, error) {
+	localParams, timeCost, msg, err := execute(nsid, gql, paramList, timeout, w)
+	return StreamResult{
+		TimeCost:    timeCost,
+		LocalParams: localParams,
+	}, msg, err
+}
+
+/*
+executes the items sequentially on the client of nsid in a single request,
+and returns the result of each item.
+With stopOnError the items after the first failed one are not executed.
+*/
+func ExecuteBatch(nsid string, items []pool.BatchItem, stopOnError bool) ([]BatchResult, error) {
+	client, err := pool.GetClient(nsid)
+	if err != nil {
+		return nil, err
+	}
+	responseChannel := make(chan []pool.ChannelResponse)
+	client.BatchRequestChannel <- pool.BatchChannelRequest{
+		Items:           items,
+		StopOnError:     stopOnError,
+		ResponseChannel: responseChannel,
+	}
+	responses := <-responseChannel
+
+	results := make([]BatchResult, 0, len(responses))
+	for i, response := range responses {
+		result := ExecuteResult{
+			Headers:     make([]string, 0),
+			Tables:      make([]map[string]types.Any, 0),
+			LocalParams: nil,
+		}
+		localParams, timeCost, msg, err := convertResponse(response, &tableWriter{result: &result})
+		result.LocalParams = localParams
+		result.TimeCost = timeCost
+		interrupted := i+1 < len(responses) && responses[i+1].Error == pool.NotExecutedError
+		if err != nil && err != pool.SessionLostError && interrupted && !strings.Contains(err.Error(), pool.InterruptError.Error()) {
+			err = fmt.Errorf("%s. %s.\n", err.Error(), pool.InterruptError.Error())
+		}
+		results = append(results, BatchResult{
+			Result: result,
+			Msg:    msg,
+			Error:  err,
+		})
+	}
+	return results, nil
+}
+
+func execute(nsid string, gql string, paramList types.ParameterList, timeout time.Duration, w ResultWriter) (types.ParameterMap, int64, interface{}, error) {
 	client, err := pool.GetClient(nsid)
 	if err != nil {
-		return result, nil, err
+		return nil, 0, nil, err
 	}
-	responseChannel := make(chan pool.ChannelResponse)
-	client.RequestChannel <- pool.ChannelRequest{
+	// buffered, so the late response after a timeout will not block the client
+	responseChannel := make(chan pool.ChannelResponse, 1)
+	request := pool.ChannelRequest{
 		Gql:             gql,
 		ResponseChannel: responseChannel,
 		ParamList:       paramList,
 	}
-	response := <-responseChannel
+	if timeout <= 0 {
+		client.RequestChannel <- request
+		return convertResponse(<-responseChannel, w)
+	}
+
+	// tracked before sending, so the request can be cancelled however early the timeout fires
+	client.TrackRequest(responseChannel)
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case client.RequestChannel <- request:
+	case <-timer.C:
+		client.UntrackRequest(responseChannel)
+		return nil, 0, nil, pool.ExecutionTimeoutError
+	}
+	select {
+	case response := <-responseChannel:
+		return convertResponse(response, w)
+	case <-timer.C:
+		// the kill error is returned as the message, so the timeout error stays distinct
+		if err := client.KillRunningQueries(responseChannel); err != nil {
+			return nil, 0, fmt.Sprintf("kill running queries of %s failed: %s", nsid, err), pool.ExecutionTimeoutError
+		}
+		return nil, 0, nil, pool.ExecutionTimeoutError
+	}
+}
+
+func convertResponse(response pool.ChannelResponse, w ResultWriter) (types.ParameterMap, int64, interface{}, error) {
+	var localParams types.ParameterMap
 	paramsMap := response.Params
 	if len(paramsMap) > 0 {
-		result.LocalParams = paramsMap
+		localParams = paramsMap
 	}
 	if response.Error != nil {
-		return result, response.Msg, response.Error
+		return localParams, 0, response.Msg, response.Error
 	}
 	res := response.Result
 	if response.Result == nil {
-		return result, nil, nil
+		return localParams, 0, nil, nil
 	}
 	if res.IsSetPlanDesc() {
 		resp := response.Result
 		if response.Result == nil {
-			return result, nil, nil
+			return localParams, 0, nil, nil
 		}
 		format := string(resp.GetPlanDesc().GetFormat())
 		if format == "row" {
-			result.Headers = []string{"id", "name", "dependencies", "profiling data", "operator info"}
+			headers := []string{"id", "name", "dependencies", "profiling data", "operator info"}
+			if err := w.WriteHeaders(headers); err != nil {
+				return localParams, 0, nil, err
+			}
 			rows := res.MakePlanByRow()
 			for i := 0; i < len(rows); i++ {
 				var rowValue = make(map[string]types.Any)
@@ -349,34 +502,42 @@ func Execute(nsid string, gql string, paramList types.ParameterList) (ExecuteRes
 				rowValue["dependencies"] = rows[i][2]
 				rowValue["profiling data"] = rows[i][3]
 				rowValue["operator info"] = rows[i][4]
-				result.Tables = append(result.Tables, rowValue)
+				if err := w.WriteRow(rowValue); err != nil {
+					return localParams, 0, nil, err
+				}
 			}
-			return result, nil, err
+			return localParams, 0, nil, nil
 		} else {
 			var rowValue = make(map[string]types.Any)
-			result.Headers = append(result.Headers, "format")
+			if err := w.WriteHeaders([]string{"format"}); err != nil {
+				return localParams, 0, nil, err
+			}
 			if format == "dot" {
 				rowValue["format"] = res.MakeDotGraph()
 			} else if format == "dot:struct" {
 				rowValue["format"] = res.MakeDotGraphByStruct()
 			}
-			result.Tables = append(result.Tables, rowValue)
-			return result, nil, err
+			if err := w.WriteRow(rowValue); err != nil {
+				return localParams, 0, nil, err
+			}
+			return localParams, 0, nil, nil
 		}
 	}
 	if !res.IsSucceed() {
-		return result, nil, errors.New(res.GetErrorMsg())
+		return localParams, 0, nil, errors.New(res.GetErrorMsg())
 	}
 	if !res.IsEmpty() {
 		records, err := res.GetRecords()
 		if err != nil {
-			return result, nil, err
+			return localParams, 0, nil, err
 		}
 
 		rowSize := len(records)
 		colSize := res.GetColSize()
 		colNames := res.GetColNames()
-		result.Headers = colNames
+		if err := w.WriteHeaders(colNames); err != nil {
+			return localParams, 0, nil, err
+		}
 
 		for i := 0; i < rowSize; i++ {
 			var rowValue = make(map[string]types.Any)
@@ -387,13 +548,13 @@ func Execute(nsid string, gql string, paramList types.ParameterList) (ExecuteRes
 			for j := 0; j < colSize; j++ {
 				rowData, err := records[i].GetValueByIndex(j)
 				if err != nil {
-					return result, nil, err
+					return localParams, 0, nil, err
 				}
 				value, err := getValue(rowData)
 				if err != nil {
-					return result, nil, err
+					return localParams, 0, nil, err
 				}
-				rowValue[result.Headers[j]] = value
+				rowValue[colNames[j]] = value
 				valueType := rowData.GetType()
 				if valueType == "vertex" {
 					var parseValue = make(map[string]types.Any)
@@ -427,12 +588,13 @@ func Execute(nsid string, gql string, paramList types.ParameterList) (ExecuteRes
 					rowValue["_pathsParsedList"] = _pathsParsedList
 				}
 				if err != nil {
-					return result, nil, err
+					return localParams, 0, nil, err
 				}
 			}
-			result.Tables = append(result.Tables, rowValue)
+			if err := w.WriteRow(rowValue); err != nil {
+				return localParams, 0, nil, err
+			}
 		}
 	}
-	result.TimeCost = res.GetLatency()
-	return result, nil, nil
+	return localParams, res.GetLatency(), nil, nil
 }
@@ -0,0 +1,249 @@
+package dao
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
+)
+
+// ServiceClient is a client of the full-text service, Password is only used to sign in
+type ServiceClient struct {
+	Host     string `json:"host"`
+	User     string `json:"user"`
+	Password string `json:"password,omitempty"`
+	// ConnType is http or https, it's supported since 3.0
+	ConnType string `json:"connType"`
+}
+
+type Listener struct {
+	Type   string `json:"type"`
+	Host   string `json:"host"`
+	PartID int32  `json:"partId"`
+	Status string `json:"status"`
+}
+
+type FTIndex struct {
+	Name string `json:"name"`
+	// Type is tag or edge
+	Type       string   `json:"type"`
+	SchemaName string   `json:"schemaName"`
+	Fields     []string `json:"fields"`
+}
+
+// SignInService signs in the clients of the full-text service, typ is elasticsearch
+func SignInService(nsid string, typ string, clients []ServiceClient) error {
+	serviceClients := make([]types.ServiceClient, 0, len(clients))
+	for _, client := range clients {
+		host, err := parseHostAddr(client.Host)
+		if err != nil {
+			return err
+		}
+		serviceClients = append(serviceClients, types.ServiceClient{
+			Host:     host,
+			User:     client.User,
+			Password: client.Password,
+			ConnType: client.ConnType,
+		})
+	}
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.SignInService(types.ServiceType(strings.ToUpper(typ)), serviceClients)
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "sign in "+typ+" service")
+	})
+}
+
+func SignOutService(nsid string, typ string) error {
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.SignOutService(types.ServiceType(strings.ToUpper(typ)))
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "sign out "+typ+" service")
+	})
+}
+
+func ListServiceClients(nsid string, typ string) ([]ServiceClient, error) {
+	clients := make([]ServiceClient, 0)
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.ListServiceClients(types.ServiceType(strings.ToUpper(typ)))
+		if err != nil {
+			return err
+		}
+		if err := metaCodeError(resp, "list "+typ+" clients"); err != nil {
+			return err
+		}
+		for _, client := range resp.GetClients() {
+			clients = append(clients, ServiceClient{
+				Host:     formatHostAddr(client.Host),
+				User:     client.User,
+				ConnType: client.ConnType,
+			})
+		}
+		return nil
+	})
+	return clients, err
+}
+
+// AddListener adds the listeners of space in the form of "ip:port", typ is elasticsearch
+func AddListener(nsid string, space string, typ string, hosts []string) error {
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.AddListener(space, types.ListenerType(strings.ToUpper(typ)), hosts)
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "add listener of "+space)
+	})
+}
+
+func RemoveListener(nsid string, space string, typ string) error {
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.RemoveListener(space, types.ListenerType(strings.ToUpper(typ)))
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "remove listener of "+space)
+	})
+}
+
+// ListListeners lists the listener of each partition of space
+func ListListeners(nsid string, space string) ([]Listener, error) {
+	listeners := make([]Listener, 0)
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.ListListener(space)
+		if err != nil {
+			return err
+		}
+		if err := metaCodeError(resp, "list listeners of "+space); err != nil {
+			return err
+		}
+		for _, listener := range resp.GetListeners() {
+			listeners = append(listeners, Listener{
+				Type:   string(listener.Type),
+				Host:   formatHostAddr(listener.Host),
+				PartID: listener.PartID,
+				Status: hostStatusNames[listener.Status],
+			})
+		}
+		return nil
+	})
+	return listeners, err
+}
+
+// CreateFTIndex creates the full-text index, the service and the listeners should be added first
+func CreateFTIndex(nsid string, space string, index FTIndex) error {
+	desc := types.FTIndexDesc{
+		Name:       index.Name,
+		SchemaName: index.SchemaName,
+		Fields:     index.Fields,
+	}
+	switch index.Type {
+	case "tag":
+	case "edge":
+		desc.IsEdge = true
+	default:
+		return UnknownIndexTypeError
+	}
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.CreateFTIndex(space, desc)
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "create fulltext index "+index.Name)
+	})
+}
+
+func DropFTIndex(nsid string, space string, name string) error {
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.DropFTIndex(space, name)
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "drop fulltext index "+name)
+	})
+}
+
+// ListFTIndexes lists the full-text indexes of space with the names of the tags and the edge types
+func ListFTIndexes(nsid string, space string) ([]FTIndex, error) {
+	indexes := make([]FTIndex, 0)
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		spaceResp, err := metaClient.GetSpace(space)
+		if err != nil {
+			return err
+		}
+		if err := metaCodeError(spaceResp, "get space "+space); err != nil {
+			return err
+		}
+		resp, err := metaClient.ListFTIndexes()
+		if err != nil {
+			return err
+		}
+		if err := metaCodeError(resp, "list fulltext indexes"); err != nil {
+			return err
+		}
+
+		var tagNames, edgeNames map[int32]string
+		for _, item := range resp.GetIndexes() {
+			if item.SpaceID != spaceResp.GetSpace().ID {
+				continue
+			}
+			index := FTIndex{
+				Name:   item.Name,
+				Type:   "tag",
+				Fields: item.Fields,
+			}
+			if item.IsEdge {
+				index.Type = "edge"
+				if edgeNames == nil {
+					if edgeNames, err = getSchemaNames(metaClient.ListEdges(space)); err != nil {
+						return err
+					}
+				}
+				index.SchemaName = edgeNames[item.SchemaID]
+			} else {
+				if tagNames == nil {
+					if tagNames, err = getSchemaNames(metaClient.ListTags(space)); err != nil {
+						return err
+					}
+				}
+				index.SchemaName = tagNames[item.SchemaID]
+			}
+			indexes = append(indexes, index)
+		}
+		return nil
+	})
+	return indexes, err
+}
+
+func getSchemaNames(resp types.SchemaItems, err error) (map[int32]string, error) {
+	if err != nil {
+		return nil, err
+	}
+	if err := metaCodeError(resp, "list schemas"); err != nil {
+		return nil, err
+	}
+	names := make(map[int32]string, len(resp.GetItems()))
+	for _, item := range resp.GetItems() {
+		names[item.ID] = item.Name
+	}
+	return names, nil
+}
+
+func parseHostAddr(endpoint string) (types.HostAddr, error) {
+	host, portStr, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return types.HostAddr{}, err
+	}
+	port, err := strconv.ParseInt(portStr, 10, 32)
+	if err != nil {
+		return types.HostAddr{}, err
+	}
+	return types.HostAddr{
+		Host: host,
+		Port: int32(port),
+	}, nil
+}
@@ -0,0 +1,213 @@
+package dao
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
+)
+
+const defaultWaitJobInterval = time.Second
+
+var WaitJobTimeoutError = errors.New("timeout to wait for the job to finish")
+
+type Job struct {
+	ID        int32    `json:"id"`
+	Cmd       string   `json:"cmd"`
+	Paras     []string `json:"paras"`
+	Status    string   `json:"status"`
+	StartTime int64    `json:"startTime"`
+	StopTime  int64    `json:"stopTime"`
+}
+
+type Task struct {
+	ID        int32  `json:"id"`
+	Host      string `json:"host"`
+	Status    string `json:"status"`
+	StartTime int64  `json:"startTime"`
+	StopTime  int64  `json:"stopTime"`
+}
+
+// JobProgress counts the tasks by status, each task of a balance job moves one partition
+type JobProgress struct {
+	Total    int `json:"total"`
+	Queue    int `json:"queue"`
+	Running  int `json:"running"`
+	Finished int `json:"finished"`
+	Failed   int `json:"failed"`
+	Stopped  int `json:"stopped"`
+}
+
+type JobDetail struct {
+	Job
+	Tasks    []Task      `json:"tasks"`
+	Progress JobProgress `json:"progress"`
+	// Done is true if the job is finished, failed or stopped
+	Done bool `json:"done"`
+}
+
+/*
+`SubmitJob` submits the admin job cmd of space, e.g. COMPACT, STATS or REBUILD_TAG_INDEX,
+paras are the index names to rebuild or the hdfs url to download
+*/
+func SubmitJob(nsid string, space string, cmd string, paras []string) (int32, error) {
+	var jobID int32
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.SubmitJob(space, types.JobCmd(strings.ToUpper(cmd)), paras)
+		if err != nil {
+			return err
+		}
+		if err := metaCodeError(resp, "submit job "+cmd); err != nil {
+			return err
+		}
+		jobID = resp.GetJobID()
+		return nil
+	})
+	return jobID, err
+}
+
+func ListJobs(nsid string, space string) ([]Job, error) {
+	jobs := make([]Job, 0)
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.ListJobs(space)
+		if err != nil {
+			return err
+		}
+		if err := metaCodeError(resp, "list jobs"); err != nil {
+			return err
+		}
+		for _, job := range resp.GetJobs() {
+			jobs = append(jobs, convertJob(job))
+		}
+		return nil
+	})
+	return jobs, err
+}
+
+func ShowJob(nsid string, space string, id int32) (*JobDetail, error) {
+	var detail *JobDetail
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.ShowJob(space, id)
+		if err != nil {
+			return err
+		}
+		if err := metaCodeError(resp, fmt.Sprintf("show job %d", id)); err != nil {
+			return err
+		}
+		detail = convertJobDetail(resp)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return detail, nil
+}
+
+func StopJob(nsid string, space string, id int32) error {
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.StopJob(space, id)
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, fmt.Sprintf("stop job %d", id))
+	})
+}
+
+// RecoverJob requeues the failed and stopped jobs of ids, or all of them if ids is empty
+func RecoverJob(nsid string, space string, ids []int32) (int32, error) {
+	var recovered int32
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.RecoverJob(space, ids)
+		if err != nil {
+			return err
+		}
+		if err := metaCodeError(resp, "recover jobs"); err != nil {
+			return err
+		}
+		recovered = resp.GetRecoveredJobNum()
+		return nil
+	})
+	return recovered, err
+}
+
+/*
+`WaitJob` polls the job every interval until it's done or timeout,
+the meta client is released between the polls so the other requests of nsid are not blocked
+*/
+func WaitJob(nsid string, space string, id int32, timeout time.Duration, interval time.Duration) (*JobDetail, error) {
+	if interval <= 0 {
+		interval = defaultWaitJobInterval
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		detail, err := ShowJob(nsid, space, id)
+		if err != nil {
+			return nil, err
+		}
+		if detail.Done {
+			return detail, nil
+		}
+		if !time.Now().Add(interval).Before(deadline) {
+			return detail, WaitJobTimeoutError
+		}
+		time.Sleep(interval)
+	}
+}
+
+func convertJob(job types.JobDesc) Job {
+	paras := job.Paras
+	if paras == nil {
+		paras = make([]string, 0)
+	}
+	return Job{
+		ID:        job.ID,
+		Cmd:       string(job.Cmd),
+		Paras:     paras,
+		Status:    string(job.Status),
+		StartTime: job.StartTime,
+		StopTime:  job.StopTime,
+	}
+}
+
+func convertJobDetail(resp types.JobDetail) *JobDetail {
+	detail := &JobDetail{
+		Job:   convertJob(resp.GetJob()),
+		Tasks: make([]Task, 0, len(resp.GetTasks())),
+	}
+	for _, task := range resp.GetTasks() {
+		detail.Tasks = append(detail.Tasks, Task{
+			ID:        task.ID,
+			Host:      fmt.Sprintf("%s:%d", task.Host.Host, task.Host.Port),
+			Status:    string(task.Status),
+			StartTime: task.StartTime,
+			StopTime:  task.StopTime,
+		})
+		detail.Progress.Total++
+		switch task.Status {
+		case types.JobStatusQueue:
+			detail.Progress.Queue++
+		case types.JobStatusRunning:
+			detail.Progress.Running++
+		case types.JobStatusFinished:
+			detail.Progress.Finished++
+		case types.JobStatusFailed:
+			detail.Progress.Failed++
+		case types.JobStatusStopped:
+			detail.Progress.Stopped++
+		}
+	}
+	detail.Done = isJobDone(detail.Job.Status)
+	return detail
+}
+
+// isJobDone reports whether the job of status will not run any more
+func isJobDone(status string) bool {
+	switch status {
+	case string(types.JobStatusFinished), string(types.JobStatusFailed), string(types.JobStatusStopped):
+		return true
+	}
+	return false
+}
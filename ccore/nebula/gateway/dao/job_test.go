@@ -0,0 +1,108 @@
+package dao
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/errors"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
+)
+
+type fakeJobDetail struct {
+	job   types.JobDesc
+	tasks []types.TaskDesc
+}
+
+func (d fakeJobDetail) GetCode() errors.ErrorCode { return errors.ErrorCode_SUCCEEDED }
+func (d fakeJobDetail) GetLeader() string         { return "" }
+func (d fakeJobDetail) GetJob() types.JobDesc     { return d.job }
+func (d fakeJobDetail) GetTasks() []types.TaskDesc {
+	return d.tasks
+}
+
+func TestConvertJobDetail(t *testing.T) {
+	host := types.HostAddr{Host: "h1", Port: 9779}
+	task := func(id int32, status types.JobStatus) types.TaskDesc {
+		return types.TaskDesc{ID: id, JobID: 1, Host: host, Status: status}
+	}
+
+	cases := []struct {
+		name     string
+		status   types.JobStatus
+		tasks    []types.TaskDesc
+		progress JobProgress
+		done     bool
+	}{
+		{
+			name:   "queued without tasks",
+			status: types.JobStatusQueue,
+		},
+		{
+			name:   "running",
+			status: types.JobStatusRunning,
+			tasks: []types.TaskDesc{
+				task(1, types.JobStatusFinished),
+				task(2, types.JobStatusRunning),
+				task(3, types.JobStatusQueue),
+			},
+			progress: JobProgress{Total: 3, Queue: 1, Running: 1, Finished: 1},
+		},
+		{
+			name:   "finished",
+			status: types.JobStatusFinished,
+			tasks: []types.TaskDesc{
+				task(1, types.JobStatusFinished),
+				task(2, types.JobStatusFinished),
+			},
+			progress: JobProgress{Total: 2, Finished: 2},
+			done:     true,
+		},
+		{
+			name:   "failed",
+			status: types.JobStatusFailed,
+			tasks: []types.TaskDesc{
+				task(1, types.JobStatusFinished),
+				task(2, types.JobStatusFailed),
+			},
+			progress: JobProgress{Total: 2, Finished: 1, Failed: 1},
+			done:     true,
+		},
+		{
+			name:   "stopped",
+			status: types.JobStatusStopped,
+			tasks: []types.TaskDesc{
+				task(1, types.JobStatusStopped),
+				task(2, types.JobStatus("UNKNOWN")),
+			},
+			progress: JobProgress{Total: 2, Stopped: 1},
+			done:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		detail := convertJobDetail(fakeJobDetail{
+			job:   types.JobDesc{ID: 1, Cmd: "COMPACT", Status: tc.status},
+			tasks: tc.tasks,
+		})
+		if !reflect.DeepEqual(detail.Progress, tc.progress) {
+			t.Errorf("%s: got progress %+v, want %+v", tc.name, detail.Progress, tc.progress)
+		}
+		if detail.Done != tc.done {
+			t.Errorf("%s: got done %v, want %v", tc.name, detail.Done, tc.done)
+		}
+		if detail.Job.Status != string(tc.status) {
+			t.Errorf("%s: got status %s, want %s", tc.name, detail.Job.Status, tc.status)
+		}
+		if len(detail.Tasks) != len(tc.tasks) {
+			t.Errorf("%s: got %d tasks, want %d", tc.name, len(detail.Tasks), len(tc.tasks))
+		}
+		for _, task := range detail.Tasks {
+			if task.Host != "h1:9779" {
+				t.Errorf("%s: got task host %s, want h1:9779", tc.name, task.Host)
+			}
+		}
+		if detail.Job.Paras == nil {
+			t.Errorf("%s: got nil paras", tc.name)
+		}
+	}
+}
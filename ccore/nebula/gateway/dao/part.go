@@ -0,0 +1,208 @@
+package dao
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
+)
+
+type Part struct {
+	ID int32 `json:"id"`
+	// Leader is empty if the partition has no leader
+	Leader string   `json:"leader"`
+	Peers  []string `json:"peers"`
+	Losts  []string `json:"losts"`
+}
+
+type HostPartDist struct {
+	Host     string `json:"host"`
+	Zone     string `json:"zone"`
+	Leaders  int    `json:"leaders"`
+	Replicas int    `json:"replicas"`
+}
+
+type ZonePartDist struct {
+	Zone     string `json:"zone"`
+	Leaders  int    `json:"leaders"`
+	Replicas int    `json:"replicas"`
+}
+
+/*
+`PartsReport` is the distribution of the partitions of a space,
+LeaderSkew and ReplicaSkew are the differences between the most and the least on the hosts
+*/
+type PartsReport struct {
+	Space         string         `json:"space"`
+	PartNum       int            `json:"partNum"`
+	Hosts         []HostPartDist `json:"hosts"`
+	Zones         []ZonePartDist `json:"zones"`
+	LeaderSkew    int            `json:"leaderSkew"`
+	ReplicaSkew   int            `json:"replicaSkew"`
+	NoLeaderParts []int32        `json:"noLeaderParts"`
+	LostReplicas  int            `json:"lostReplicas"`
+}
+
+// ListParts lists the partitions of partIDs in space, or all of them if partIDs is empty
+func ListParts(nsid string, space string, partIDs []int32) ([]Part, error) {
+	var parts []Part
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		items, err := listParts(metaClient, space, partIDs)
+		if err != nil {
+			return err
+		}
+		parts = make([]Part, 0, len(items))
+		for _, item := range items {
+			part := Part{
+				ID:    item.ID,
+				Peers: formatHostAddrs(item.Peers),
+				Losts: formatHostAddrs(item.Losts),
+			}
+			if item.Leader != nil {
+				part.Leader = formatHostAddr(*item.Leader)
+			}
+			parts = append(parts, part)
+		}
+		return nil
+	})
+	return parts, err
+}
+
+/*
+`GetPartsReport` counts the leaders and replicas of space on each host and zone,
+the hosts without any partition are also counted to show the skew
+*/
+func GetPartsReport(nsid string, space string) (*PartsReport, error) {
+	var report *PartsReport
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		items, err := listParts(metaClient, space, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := metaClient.ListHosts()
+		if err != nil {
+			return err
+		}
+		if err := metaCodeError(resp, "list hosts"); err != nil {
+			return err
+		}
+
+		hosts := make([]types.HostItem, 0, len(resp.GetHosts()))
+		for _, host := range resp.GetHosts() {
+			hosts = append(hosts, host.GetHostItem())
+		}
+		report = newPartsReport(space, items, hosts)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func newPartsReport(space string, items []types.PartItem, hostItems []types.HostItem) *PartsReport {
+	report := PartsReport{
+		Space:         space,
+		PartNum:       len(items),
+		Hosts:         make([]HostPartDist, 0),
+		Zones:         make([]ZonePartDist, 0),
+		NoLeaderParts: make([]int32, 0),
+	}
+
+	hosts := make(map[string]*HostPartDist)
+	for _, item := range hostItems {
+		addr := formatHostAddr(item.HostAddr)
+		hosts[addr] = &HostPartDist{
+			Host: addr,
+			Zone: string(item.ZoneName),
+		}
+	}
+	getHost := func(addr string) *HostPartDist {
+		if _, ok := hosts[addr]; !ok {
+			hosts[addr] = &HostPartDist{Host: addr}
+		}
+		return hosts[addr]
+	}
+
+	for _, item := range items {
+		if item.Leader == nil {
+			report.NoLeaderParts = append(report.NoLeaderParts, item.ID)
+		} else {
+			getHost(formatHostAddr(*item.Leader)).Leaders++
+		}
+		for _, peer := range item.Peers {
+			getHost(formatHostAddr(peer)).Replicas++
+		}
+		report.LostReplicas += len(item.Losts)
+	}
+
+	zones := make(map[string]*ZonePartDist)
+	for _, host := range hosts {
+		report.Hosts = append(report.Hosts, *host)
+		if host.Zone == "" {
+			continue
+		}
+		if _, ok := zones[host.Zone]; !ok {
+			zones[host.Zone] = &ZonePartDist{Zone: host.Zone}
+		}
+		zones[host.Zone].Leaders += host.Leaders
+		zones[host.Zone].Replicas += host.Replicas
+	}
+	for _, zone := range zones {
+		report.Zones = append(report.Zones, *zone)
+	}
+
+	sort.Slice(report.Hosts, func(i, j int) bool {
+		return report.Hosts[i].Host < report.Hosts[j].Host
+	})
+	sort.Slice(report.Zones, func(i, j int) bool {
+		return report.Zones[i].Zone < report.Zones[j].Zone
+	})
+	var minLeaders, maxLeaders, minReplicas, maxReplicas int
+	for i, host := range report.Hosts {
+		if i == 0 {
+			minLeaders, maxLeaders = host.Leaders, host.Leaders
+			minReplicas, maxReplicas = host.Replicas, host.Replicas
+			continue
+		}
+		if host.Leaders < minLeaders {
+			minLeaders = host.Leaders
+		}
+		if host.Leaders > maxLeaders {
+			maxLeaders = host.Leaders
+		}
+		if host.Replicas < minReplicas {
+			minReplicas = host.Replicas
+		}
+		if host.Replicas > maxReplicas {
+			maxReplicas = host.Replicas
+		}
+	}
+	report.LeaderSkew = maxLeaders - minLeaders
+	report.ReplicaSkew = maxReplicas - minReplicas
+	return &report
+}
+
+func listParts(metaClient nebula.MetaClient, space string, partIDs []int32) ([]types.PartItem, error) {
+	resp, err := metaClient.ListParts(space, partIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err := metaCodeError(resp, "list parts of "+space); err != nil {
+		return nil, err
+	}
+	return resp.GetParts(), nil
+}
+
+func formatHostAddr(addr types.HostAddr) string {
+	return fmt.Sprintf("%s:%d", addr.Host, addr.Port)
+}
+
+func formatHostAddrs(addrs []types.HostAddr) []string {
+	hosts := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		hosts = append(hosts, formatHostAddr(addr))
+	}
+	return hosts
+}
@@ -0,0 +1,112 @@
+package dao
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
+)
+
+func TestNewPartsReport(t *testing.T) {
+	h1 := types.HostAddr{Host: "h1", Port: 9779}
+	h2 := types.HostAddr{Host: "h2", Port: 9779}
+	h3 := types.HostAddr{Host: "h3", Port: 9779}
+	hostItems := []types.HostItem{
+		{HostAddr: h1, ZoneName: []byte("z1")},
+		{HostAddr: h2, ZoneName: []byte("z1")},
+		{HostAddr: h3, ZoneName: []byte("z2")},
+	}
+
+	cases := []struct {
+		name   string
+		items  []types.PartItem
+		hosts  []types.HostItem
+		expect PartsReport
+	}{
+		{
+			name:  "empty",
+			items: nil,
+			hosts: nil,
+			expect: PartsReport{
+				Hosts:         []HostPartDist{},
+				Zones:         []ZonePartDist{},
+				NoLeaderParts: []int32{},
+			},
+		},
+		{
+			name: "balanced",
+			items: []types.PartItem{
+				{ID: 1, Leader: &h1, Peers: []types.HostAddr{h1, h2, h3}},
+				{ID: 2, Leader: &h2, Peers: []types.HostAddr{h1, h2, h3}},
+				{ID: 3, Leader: &h3, Peers: []types.HostAddr{h1, h2, h3}},
+			},
+			hosts: hostItems,
+			expect: PartsReport{
+				PartNum: 3,
+				Hosts: []HostPartDist{
+					{Host: "h1:9779", Zone: "z1", Leaders: 1, Replicas: 3},
+					{Host: "h2:9779", Zone: "z1", Leaders: 1, Replicas: 3},
+					{Host: "h3:9779", Zone: "z2", Leaders: 1, Replicas: 3},
+				},
+				Zones: []ZonePartDist{
+					{Zone: "z1", Leaders: 2, Replicas: 6},
+					{Zone: "z2", Leaders: 1, Replicas: 3},
+				},
+				NoLeaderParts: []int32{},
+			},
+		},
+		{
+			name: "skewed",
+			items: []types.PartItem{
+				{ID: 1, Leader: &h1, Peers: []types.HostAddr{h1, h2}, Losts: []types.HostAddr{h3}},
+				{ID: 2, Leader: &h1, Peers: []types.HostAddr{h1, h2}},
+				{ID: 3, Peers: []types.HostAddr{h1}, Losts: []types.HostAddr{h2, h3}},
+			},
+			hosts: hostItems,
+			expect: PartsReport{
+				PartNum: 3,
+				Hosts: []HostPartDist{
+					{Host: "h1:9779", Zone: "z1", Leaders: 2, Replicas: 3},
+					{Host: "h2:9779", Zone: "z1", Leaders: 0, Replicas: 2},
+					{Host: "h3:9779", Zone: "z2", Leaders: 0, Replicas: 0},
+				},
+				Zones: []ZonePartDist{
+					{Zone: "z1", Leaders: 2, Replicas: 5},
+					{Zone: "z2", Leaders: 0, Replicas: 0},
+				},
+				LeaderSkew:    2,
+				ReplicaSkew:   3,
+				NoLeaderParts: []int32{3},
+				LostReplicas:  3,
+			},
+		},
+		{
+			name: "peer not in hosts",
+			items: []types.PartItem{
+				{ID: 1, Leader: &h3, Peers: []types.HostAddr{h3}},
+			},
+			hosts: hostItems[:1],
+			expect: PartsReport{
+				PartNum: 1,
+				Hosts: []HostPartDist{
+					{Host: "h1:9779", Zone: "z1", Leaders: 0, Replicas: 0},
+					{Host: "h3:9779", Leaders: 1, Replicas: 1},
+				},
+				Zones: []ZonePartDist{
+					{Zone: "z1"},
+				},
+				LeaderSkew:    1,
+				ReplicaSkew:   1,
+				NoLeaderParts: []int32{},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc.expect.Space = "space"
+		report := newPartsReport("space", tc.items, tc.hosts)
+		if !reflect.DeepEqual(*report, tc.expect) {
+			t.Errorf("%s: got %+v, want %+v", tc.name, *report, tc.expect)
+		}
+	}
+}
@@ -0,0 +1,633 @@
+package dao
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula"
+	nerrors "github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/errors"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/gateway/pool"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/wrapper"
+)
+
+var (
+	UnknownIndexTypeError    = errors.New("unknown index type, it should be tag or edge")
+	InvalidPropertyTypeError = errors.New("invalid property type, it should be like int64, fixed_string(32) or geography(point)")
+)
+
+// propertyTypePattern matches the formatted property types, e.g. fixed_string(32)
+var propertyTypePattern = regexp.MustCompile(`^(\w+)(?:\((\w+)\))?$`)
+
+type Property struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Nullable bool   `json:"nullable"`
+	// Default is null if there is no default or it's not a constant, see HasDefault
+	Default    types.Any `json:"default"`
+	HasDefault bool      `json:"hasDefault"`
+	Comment    string    `json:"comment"`
+}
+
+type Schema struct {
+	ID          int32      `json:"id"`
+	Name        string     `json:"name"`
+	Version     int64      `json:"version"`
+	Properties  []Property `json:"properties"`
+	TTLDuration int64      `json:"ttlDuration"`
+	TTLCol      string     `json:"ttlCol"`
+	Comment     string     `json:"comment"`
+}
+
+/*
+`SchemaAlter` is the alteration of a tag or an edge type, the properties are added, changed and dropped in order,
+the ttl is altered if TTLCol is set, and it's removed if TTLCol is an empty string
+*/
+type SchemaAlter struct {
+	Add         []Property `json:"add"`
+	Change      []Property `json:"change"`
+	Drop        []string   `json:"drop"`
+	TTLDuration int64      `json:"ttlDuration"`
+	TTLCol      *string    `json:"ttlCol"`
+	Comment     string     `json:"comment"`
+}
+
+type IndexField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type Index struct {
+	ID   int32  `json:"id"`
+	Name string `json:"name"`
+	// Type is tag or edge
+	Type       string       `json:"type"`
+	SchemaName string       `json:"schemaName"`
+	Fields     []IndexField `json:"fields"`
+	Comment    string       `json:"comment"`
+}
+
+// IndexFieldDesc is a field of an index to create, Length is the prefix length of a string field
+type IndexFieldDesc struct {
+	Name   string `json:"name"`
+	Length int16  `json:"length"`
+}
+
+type IndexDesc struct {
+	Name string `json:"name"`
+	// Type is tag or edge
+	Type       string           `json:"type"`
+	SchemaName string           `json:"schemaName"`
+	Fields     []IndexFieldDesc `json:"fields"`
+	Comment    string           `json:"comment"`
+}
+
+// IndexStatus is the status of the last rebuild job of an index, e.g. FINISHED
+type IndexStatus struct {
+	Name string `json:"name"`
+	// Type is tag or edge
+	Type   string `json:"type"`
+	Status string `json:"status"`
+}
+
+// IndexJob is the rebuild job of the indexes
+type IndexJob struct {
+	JobID  int32  `json:"jobId"`
+	Status string `json:"status"`
+	// Done is true if the job is finished, failed or stopped
+	Done bool `json:"done"`
+}
+
+func newIndexJob(id int32, status string) *IndexJob {
+	return &IndexJob{
+		JobID:  id,
+		Status: status,
+		Done:   isJobDone(status),
+	}
+}
+
+func ListTags(nsid string, space string) ([]Schema, error) {
+	return listSchemas(nsid, space, "tags", func(metaClient nebula.MetaClient) (types.SchemaItems, error) {
+		return metaClient.ListTags(space)
+	})
+}
+
+func GetTag(nsid string, space string, name string) (*Schema, error) {
+	return getSchema(nsid, space, name, func(metaClient nebula.MetaClient) (types.SchemaResult, error) {
+		return metaClient.GetTag(space, name)
+	})
+}
+
+func ListEdges(nsid string, space string) ([]Schema, error) {
+	return listSchemas(nsid, space, "edges", func(metaClient nebula.MetaClient) (types.SchemaItems, error) {
+		return metaClient.ListEdges(space)
+	})
+}
+
+func GetEdge(nsid string, space string, name string) (*Schema, error) {
+	return getSchema(nsid, space, name, func(metaClient nebula.MetaClient) (types.SchemaResult, error) {
+		return metaClient.GetEdge(space, name)
+	})
+}
+
+/*
+`ListIndexes` lists the tag and edge indexes of space,
+indexType filters them by tag or edge if it's not empty
+*/
+func ListIndexes(nsid string, space string, indexType string) ([]Index, error) {
+	client, err := pool.GetClient(nsid)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := make([]Index, 0)
+	err = client.SpaceDo(space, func(metaClient nebula.MetaClient) error {
+		if indexType == "" || indexType == "tag" {
+			resp, err := metaClient.ListTagIndexes(space)
+			if err != nil {
+				return err
+			}
+			if err := metaCodeError(resp, "list tag indexes"); err != nil {
+				return err
+			}
+			indexes = append(indexes, convertIndexes(resp.GetItems())...)
+		}
+		if indexType == "" || indexType == "edge" {
+			resp, err := metaClient.ListEdgeIndexes(space)
+			if err != nil {
+				return err
+			}
+			if err := metaCodeError(resp, "list edge indexes"); err != nil {
+				return err
+			}
+			indexes = append(indexes, convertIndexes(resp.GetItems())...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return indexes, nil
+}
+
+/*
+`CreateTag` creates the tag of schema, the type of a property is formatted as the one read, e.g. fixed_string(32),
+the default is used if it's not null or HasDefault is set, and it should be a constant bool, number, string or null
+*/
+func CreateTag(nsid string, space string, schema Schema, ifNotExists bool) error {
+	return createSchema(nsid, "create tag "+schema.Name, schema, func(metaClient nebula.MetaClient, nSchema types.Schema) (types.MetaBaser, error) {
+		return metaClient.CreateTag(space, schema.Name, nSchema, ifNotExists)
+	})
+}
+
+func AlterTag(nsid string, space string, name string, alter SchemaAlter) error {
+	return alterSchema(nsid, "alter tag "+name, alter, func(metaClient nebula.MetaClient, items []types.AlterSchemaItem, prop *types.SchemaProp) (types.MetaBaser, error) {
+		return metaClient.AlterTag(space, name, items, prop)
+	}, func(metaClient nebula.MetaClient) (types.SchemaResult, error) {
+		return metaClient.GetTag(space, name)
+	})
+}
+
+func DropTag(nsid string, space string, name string, ifExists bool) error {
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.DropTag(space, name, ifExists)
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "drop tag "+name)
+	})
+}
+
+func CreateEdge(nsid string, space string, schema Schema, ifNotExists bool) error {
+	return createSchema(nsid, "create edge "+schema.Name, schema, func(metaClient nebula.MetaClient, nSchema types.Schema) (types.MetaBaser, error) {
+		return metaClient.CreateEdge(space, schema.Name, nSchema, ifNotExists)
+	})
+}
+
+func AlterEdge(nsid string, space string, name string, alter SchemaAlter) error {
+	return alterSchema(nsid, "alter edge "+name, alter, func(metaClient nebula.MetaClient, items []types.AlterSchemaItem, prop *types.SchemaProp) (types.MetaBaser, error) {
+		return metaClient.AlterEdge(space, name, items, prop)
+	}, func(metaClient nebula.MetaClient) (types.SchemaResult, error) {
+		return metaClient.GetEdge(space, name)
+	})
+}
+
+func DropEdge(nsid string, space string, name string, ifExists bool) error {
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.DropEdge(space, name, ifExists)
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "drop edge "+name)
+	})
+}
+
+// CreateIndex creates the tag or edge index of desc, the index should be rebuilt for the existing data
+func CreateIndex(nsid string, space string, desc IndexDesc, ifNotExists bool) error {
+	indexDesc := types.IndexDesc{
+		Name:       desc.Name,
+		SchemaName: desc.SchemaName,
+		Fields:     make([]types.IndexField, 0, len(desc.Fields)),
+		Comment:    desc.Comment,
+	}
+	for _, field := range desc.Fields {
+		indexDesc.Fields = append(indexDesc.Fields, types.IndexField{
+			Name:   field.Name,
+			Length: field.Length,
+		})
+	}
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		var (
+			resp types.MetaBaser
+			err  error
+		)
+		switch desc.Type {
+		case "tag":
+			resp, err = metaClient.CreateTagIndex(space, indexDesc, ifNotExists)
+		case "edge":
+			resp, err = metaClient.CreateEdgeIndex(space, indexDesc, ifNotExists)
+		default:
+			return UnknownIndexTypeError
+		}
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "create index "+desc.Name)
+	})
+}
+
+// DropIndex drops the index, indexType is tag or edge
+func DropIndex(nsid string, space string, indexType string, name string, ifExists bool) error {
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		var (
+			resp types.MetaBaser
+			err  error
+		)
+		switch indexType {
+		case "tag":
+			resp, err = metaClient.DropTagIndex(space, name, ifExists)
+		case "edge":
+			resp, err = metaClient.DropEdgeIndex(space, name, ifExists)
+		default:
+			return UnknownIndexTypeError
+		}
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "drop index "+name)
+	})
+}
+
+// ListIndexStatus lists the rebuild status of the indexes of space, indexType filters them by tag or edge if it's not empty
+func ListIndexStatus(nsid string, space string, indexType string) ([]IndexStatus, error) {
+	statuses := make([]IndexStatus, 0)
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		for _, typ := range []string{"tag", "edge"} {
+			if indexType != "" && indexType != typ {
+				continue
+			}
+			var (
+				resp types.IndexStatuses
+				err  error
+			)
+			if typ == "tag" {
+				resp, err = metaClient.ListTagIndexStatus(space)
+			} else {
+				resp, err = metaClient.ListEdgeIndexStatus(space)
+			}
+			if err != nil {
+				return err
+			}
+			if err := metaCodeError(resp, "list "+typ+" index status"); err != nil {
+				return err
+			}
+			for _, status := range resp.GetStatuses() {
+				statuses = append(statuses, IndexStatus{
+					Name:   status.Name,
+					Type:   typ,
+					Status: string(status.Status),
+				})
+			}
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// RebuildIndex submits the rebuild job of the tag or edge indexes, all the indexes of the type are rebuilt if indexes is empty
+func RebuildIndex(nsid string, space string, indexType string, indexes []string) (*IndexJob, error) {
+	var job *IndexJob
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		var (
+			rebuilder types.IndexRebuilder
+			err       error
+		)
+		switch indexType {
+		case "tag":
+			rebuilder, err = metaClient.RebuildTagIndex(space, indexes)
+		case "edge":
+			rebuilder, err = metaClient.RebuildEdgeIndex(space, indexes)
+		default:
+			return UnknownIndexTypeError
+		}
+		if err != nil {
+			return err
+		}
+		if err := metaCodeError(rebuilder, "rebuild "+indexType+" index"); err != nil {
+			return err
+		}
+		status, err := rebuilder.GetStatus()
+		if err != nil {
+			return err
+		}
+		job = newIndexJob(rebuilder.GetJobID(), string(status))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetIndexJob gets the status of the rebuild job to poll until it's done
+func GetIndexJob(nsid string, space string, id int32) (*IndexJob, error) {
+	detail, err := ShowJob(nsid, space, id)
+	if err != nil {
+		return nil, err
+	}
+	return newIndexJob(detail.ID, detail.Status), nil
+}
+
+func listSchemas(nsid string, space string, kind string, list func(metaClient nebula.MetaClient) (types.SchemaItems, error)) ([]Schema, error) {
+	client, err := pool.GetClient(nsid)
+	if err != nil {
+		return nil, err
+	}
+
+	var schemas []Schema
+	err = client.SpaceDo(space, func(metaClient nebula.MetaClient) error {
+		resp, err := list(metaClient)
+		if err != nil {
+			return err
+		}
+		if err := metaCodeError(resp, "list "+kind); err != nil {
+			return err
+		}
+		schemas = make([]Schema, 0, len(resp.GetItems()))
+		for _, item := range resp.GetItems() {
+			schema := convertSchema(client, item.Schema)
+			schema.ID = item.ID
+			schema.Name = item.Name
+			schema.Version = item.Version
+			schemas = append(schemas, schema)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return schemas, nil
+}
+
+func getSchema(nsid string, space string, name string, get func(metaClient nebula.MetaClient) (types.SchemaResult, error)) (*Schema, error) {
+	client, err := pool.GetClient(nsid)
+	if err != nil {
+		return nil, err
+	}
+
+	var schema Schema
+	err = client.SpaceDo(space, func(metaClient nebula.MetaClient) error {
+		resp, err := get(metaClient)
+		if err != nil {
+			return err
+		}
+		if err := metaCodeError(resp, "get "+name); err != nil {
+			return err
+		}
+		schema = convertSchema(client, resp.GetSchema())
+		schema.Name = name
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+func createSchema(nsid string, action string, schema Schema, create func(metaClient nebula.MetaClient, nSchema types.Schema) (types.MetaBaser, error)) error {
+	client, err := pool.GetClient(nsid)
+	if err != nil {
+		return err
+	}
+	columns, err := newColumnDefs(client, schema.Properties)
+	if err != nil {
+		return err
+	}
+	nSchema := types.Schema{
+		Columns: columns,
+		Prop: types.SchemaProp{
+			TTLDuration: schema.TTLDuration,
+			TTLCol:      schema.TTLCol,
+			Comment:     schema.Comment,
+		},
+	}
+	return client.AdminDo(func(metaClient nebula.MetaClient) error {
+		resp, err := create(metaClient, nSchema)
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, action)
+	})
+}
+
+func alterSchema(nsid string, action string, alter SchemaAlter,
+	alterFn func(metaClient nebula.MetaClient, items []types.AlterSchemaItem, prop *types.SchemaProp) (types.MetaBaser, error),
+	get func(metaClient nebula.MetaClient) (types.SchemaResult, error)) error {
+	client, err := pool.GetClient(nsid)
+	if err != nil {
+		return err
+	}
+	items := make([]types.AlterSchemaItem, 0, 3)
+	if len(alter.Add) > 0 {
+		columns, err := newColumnDefs(client, alter.Add)
+		if err != nil {
+			return err
+		}
+		items = append(items, types.AlterSchemaItem{Op: types.AlterSchemaAdd, Columns: columns})
+	}
+	if len(alter.Change) > 0 {
+		columns, err := newColumnDefs(client, alter.Change)
+		if err != nil {
+			return err
+		}
+		items = append(items, types.AlterSchemaItem{Op: types.AlterSchemaChange, Columns: columns})
+	}
+	if len(alter.Drop) > 0 {
+		columns := make([]types.ColumnDef, 0, len(alter.Drop))
+		for _, name := range alter.Drop {
+			columns = append(columns, types.ColumnDef{Name: name})
+		}
+		items = append(items, types.AlterSchemaItem{Op: types.AlterSchemaDrop, Columns: columns})
+	}
+
+	return client.AdminDo(func(metaClient nebula.MetaClient) error {
+		var prop *types.SchemaProp
+		switch {
+		case alter.TTLCol != nil:
+			prop = &types.SchemaProp{
+				TTLDuration: alter.TTLDuration,
+				TTLCol:      *alter.TTLCol,
+				Comment:     alter.Comment,
+			}
+		case alter.Comment != "":
+			// the ttl is altered with the comment, so keep the current one
+			resp, err := get(metaClient)
+			if err != nil {
+				return err
+			}
+			if err := metaCodeError(resp, action); err != nil {
+				return err
+			}
+			current := resp.GetSchema().Prop
+			current.Comment = alter.Comment
+			prop = &current
+		}
+		resp, err := alterFn(metaClient, items, prop)
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, action)
+	})
+}
+
+func newColumnDefs(client *pool.Client, properties []Property) ([]types.ColumnDef, error) {
+	columns := make([]types.ColumnDef, 0, len(properties))
+	for _, property := range properties {
+		column := types.ColumnDef{
+			Name:     property.Name,
+			Nullable: property.Nullable,
+			// a null default is set by HasDefault
+			HasDefault: property.HasDefault || property.Default != nil,
+			Comment:    property.Comment,
+		}
+		if err := parsePropertyType(property.Type, &column); err != nil {
+			return nil, err
+		}
+		if column.HasDefault {
+			value, err := wrapDefaultValue(client, column.Type, property.Default)
+			if err != nil {
+				return nil, err
+			}
+			column.DefaultValue = value
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// parsePropertyType parses the type formatted by formatPropertyType into column
+func parsePropertyType(typ string, column *types.ColumnDef) error {
+	matches := propertyTypePattern.FindStringSubmatch(strings.TrimSpace(typ))
+	if matches == nil {
+		return InvalidPropertyTypeError
+	}
+	column.Type = types.PropertyType(strings.ToUpper(matches[1]))
+	if matches[2] == "" {
+		return nil
+	}
+	switch column.Type {
+	case "FIXED_STRING", "STRING":
+		length, err := strconv.ParseInt(matches[2], 10, 16)
+		if err != nil {
+			return InvalidPropertyTypeError
+		}
+		column.TypeLength = int16(length)
+	case "GEOGRAPHY":
+		column.GeoShape = strings.ToUpper(matches[2])
+	default:
+		return InvalidPropertyTypeError
+	}
+	return nil
+}
+
+// wrapDefaultValue wraps the json default, the integral numbers are kept as floats for the float properties
+func wrapDefaultValue(client *pool.Client, typ types.PropertyType, v interface{}) (types.Value, error) {
+	if f, ok := v.(float64); ok && (typ == "DOUBLE" || typ == "FLOAT") {
+		value := client.Factory().NewValueBuilder().Build()
+		value.SetFVal(&f)
+		return value, nil
+	}
+	return wrapper.WrapValue(v, client.Factory())
+}
+
+func convertSchema(client *pool.Client, schema types.Schema) Schema {
+	properties := make([]Property, 0, len(schema.Columns))
+	for _, column := range schema.Columns {
+		property := Property{
+			Name:       column.Name,
+			Type:       formatPropertyType(column),
+			Nullable:   column.Nullable,
+			HasDefault: column.HasDefault,
+			Comment:    column.Comment,
+		}
+		if column.DefaultValue != nil {
+			valWrap := wrapper.NewValueWrapper(column.DefaultValue, client.Factory(), client.TimezoneInfo())
+			property.Default, _ = getValue(valWrap)
+		}
+		properties = append(properties, property)
+	}
+	return Schema{
+		Properties:  properties,
+		TTLDuration: schema.Prop.TTLDuration,
+		TTLCol:      schema.Prop.TTLCol,
+		Comment:     schema.Prop.Comment,
+	}
+}
+
+func convertIndexes(items []types.IndexItem) []Index {
+	indexes := make([]Index, 0, len(items))
+	for _, item := range items {
+		index := Index{
+			ID:         item.ID,
+			Name:       item.Name,
+			Type:       "tag",
+			SchemaName: item.SchemaName,
+			Fields:     make([]IndexField, 0, len(item.Fields)),
+			Comment:    item.Comment,
+		}
+		if item.IsEdge {
+			index.Type = "edge"
+		}
+		for _, field := range item.Fields {
+			index.Fields = append(index.Fields, IndexField{
+				Name: field.Name,
+				Type: formatPropertyType(field),
+			})
+		}
+		indexes = append(indexes, index)
+	}
+	return indexes
+}
+
+// formatPropertyType formats the type in the same way as `DESCRIBE TAG`, e.g. fixed_string(32)
+func formatPropertyType(column types.ColumnDef) string {
+	typ := strings.ToLower(string(column.Type))
+	switch {
+	// the length of a string index field is the prefix length indexed
+	case column.Type == "FIXED_STRING", column.Type == "STRING" && column.TypeLength > 0:
+		return fmt.Sprintf("%s(%d)", typ, column.TypeLength)
+	case column.GeoShape != "" && column.GeoShape != "ANY":
+		return fmt.Sprintf("%s(%s)", typ, strings.ToLower(column.GeoShape))
+	}
+	return typ
+}
+
+func metaCodeError(resp types.MetaBaser, action string) error {
+	if resp.GetCode() == nerrors.ErrorCode_SUCCEEDED {
+		return nil
+	}
+	return nerrors.NewCodeError(resp.GetCode(), "failed to "+action)
+}
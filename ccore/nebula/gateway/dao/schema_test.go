@@ -0,0 +1,93 @@
+package dao
+
+import (
+	"testing"
+
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
+)
+
+func TestParsePropertyType(t *testing.T) {
+	cases := []struct {
+		typ    string
+		column types.ColumnDef
+		err    error
+	}{
+		{typ: "int64", column: types.ColumnDef{Type: "INT64"}},
+		{typ: " bool ", column: types.ColumnDef{Type: "BOOL"}},
+		{typ: "string", column: types.ColumnDef{Type: "STRING"}},
+		{typ: "string(16)", column: types.ColumnDef{Type: "STRING", TypeLength: 16}},
+		{typ: "fixed_string(32)", column: types.ColumnDef{Type: "FIXED_STRING", TypeLength: 32}},
+		{typ: "FIXED_STRING(32)", column: types.ColumnDef{Type: "FIXED_STRING", TypeLength: 32}},
+		{typ: "geography", column: types.ColumnDef{Type: "GEOGRAPHY"}},
+		{typ: "geography(point)", column: types.ColumnDef{Type: "GEOGRAPHY", GeoShape: "POINT"}},
+		{typ: "", err: InvalidPropertyTypeError},
+		{typ: "fixed_string(", err: InvalidPropertyTypeError},
+		{typ: "fixed_string(abc)", err: InvalidPropertyTypeError},
+		{typ: "fixed_string(40000)", err: InvalidPropertyTypeError},
+		{typ: "int64(8)", err: InvalidPropertyTypeError},
+		{typ: "list<int>", err: InvalidPropertyTypeError},
+	}
+
+	for _, tc := range cases {
+		var column types.ColumnDef
+		err := parsePropertyType(tc.typ, &column)
+		if err != tc.err {
+			t.Errorf("%q: got error %v, want %v", tc.typ, err, tc.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if column.Type != tc.column.Type || column.TypeLength != tc.column.TypeLength || column.GeoShape != tc.column.GeoShape {
+			t.Errorf("%q: got %+v, want %+v", tc.typ, column, tc.column)
+		}
+	}
+}
+
+func TestParsePropertyTypeRoundTrip(t *testing.T) {
+	columns := []types.ColumnDef{
+		{Type: "INT64"},
+		{Type: "DOUBLE"},
+		{Type: "STRING"},
+		{Type: "STRING", TypeLength: 8},
+		{Type: "FIXED_STRING", TypeLength: 32},
+		{Type: "GEOGRAPHY"},
+		{Type: "GEOGRAPHY", GeoShape: "POLYGON"},
+	}
+
+	for _, expect := range columns {
+		typ := formatPropertyType(expect)
+		var column types.ColumnDef
+		if err := parsePropertyType(typ, &column); err != nil {
+			t.Errorf("%q: %v", typ, err)
+			continue
+		}
+		if column.Type != expect.Type || column.TypeLength != expect.TypeLength || column.GeoShape != expect.GeoShape {
+			t.Errorf("%q: got %+v, want %+v", typ, column, expect)
+		}
+	}
+}
+
+func TestNewIndexJob(t *testing.T) {
+	cases := []struct {
+		status types.JobStatus
+		done   bool
+	}{
+		{types.JobStatusQueue, false},
+		{types.JobStatusRunning, false},
+		{types.JobStatusFinished, true},
+		{types.JobStatusFailed, true},
+		{types.JobStatusStopped, true},
+		{types.JobStatus("UNKNOWN"), false},
+	}
+
+	for _, tc := range cases {
+		job := newIndexJob(1, string(tc.status))
+		if job.JobID != 1 || job.Status != string(tc.status) {
+			t.Errorf("%s: got %+v", tc.status, job)
+		}
+		if job.Done != tc.done {
+			t.Errorf("%s: got done %v, want %v", tc.status, job.Done, tc.done)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package dao
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
+)
+
+var NoQueriesToKillError = errors.New("no queries to kill")
+
+// Query is a running query, the times are in microseconds
+type Query struct {
+	SessionID int64  `json:"sessionId"`
+	PlanID    int64  `json:"planId"`
+	User      string `json:"user"`
+	Query     string `json:"query"`
+	Status    string `json:"status"`
+	StartTime int64  `json:"startTime"`
+	Duration  int64  `json:"duration"`
+	GraphAddr string `json:"graphAddr"`
+}
+
+// Session is a session of graphd, the times are in microseconds
+type Session struct {
+	ID         int64   `json:"id"`
+	User       string  `json:"user"`
+	Space      string  `json:"space"`
+	GraphAddr  string  `json:"graphAddr"`
+	ClientIP   string  `json:"clientIp"`
+	Timezone   int32   `json:"timezone"`
+	CreateTime int64   `json:"createTime"`
+	UpdateTime int64   `json:"updateTime"`
+	Queries    []Query `json:"queries"`
+}
+
+// QueryToKill is a query of a session, which is shown in Session.Queries
+type QueryToKill struct {
+	SessionID int64 `json:"sessionId"`
+	PlanID    int64 `json:"planId"`
+}
+
+func ListSessions(nsid string) ([]Session, error) {
+	sessions := make([]Session, 0)
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.ListSessions()
+		if err != nil {
+			return err
+		}
+		if err := metaCodeError(resp, "list sessions"); err != nil {
+			return err
+		}
+		for _, session := range resp.GetSessions() {
+			sessions = append(sessions, convertSession(session))
+		}
+		return nil
+	})
+	return sessions, err
+}
+
+func GetSession(nsid string, id int64) (*Session, error) {
+	var session Session
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.GetSession(id)
+		if err != nil {
+			return err
+		}
+		if err := metaCodeError(resp, fmt.Sprintf("get session %d", id)); err != nil {
+			return err
+		}
+		session = convertSession(resp.GetSession())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func RemoveSession(nsid string, id int64) error {
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.RemoveSession(id)
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, fmt.Sprintf("remove session %d", id))
+	})
+}
+
+// ListQueries lists the running queries of all the sessions
+func ListQueries(nsid string) ([]Query, error) {
+	sessions, err := ListSessions(nsid)
+	if err != nil {
+		return nil, err
+	}
+	queries := make([]Query, 0)
+	for _, session := range sessions {
+		queries = append(queries, session.Queries...)
+	}
+	return queries, nil
+}
+
+func KillQueries(nsid string, queries []QueryToKill) error {
+	if len(queries) == 0 {
+		return NoQueriesToKillError
+	}
+	killQueries := make(map[int64][]int64)
+	for _, query := range queries {
+		killQueries[query.SessionID] = append(killQueries[query.SessionID], query.PlanID)
+	}
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.KillQuery(killQueries)
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "kill queries")
+	})
+}
+
+func convertSession(session types.SessionDesc) Session {
+	queries := make([]Query, 0, len(session.Queries))
+	for _, query := range session.Queries {
+		queries = append(queries, Query{
+			SessionID: session.ID,
+			PlanID:    query.PlanID,
+			User:      session.User,
+			Query:     query.Query,
+			Status:    string(query.Status),
+			StartTime: query.StartTime,
+			Duration:  query.Duration,
+			GraphAddr: fmt.Sprintf("%s:%d", query.GraphAddr.Host, query.GraphAddr.Port),
+		})
+	}
+	return Session{
+		ID:         session.ID,
+		User:       session.User,
+		Space:      session.Space,
+		GraphAddr:  fmt.Sprintf("%s:%d", session.GraphAddr.Host, session.GraphAddr.Port),
+		ClientIP:   session.ClientIP,
+		Timezone:   session.Timezone,
+		CreateTime: session.CreateTime,
+		UpdateTime: session.UpdateTime,
+		Queries:    queries,
+	}
+}
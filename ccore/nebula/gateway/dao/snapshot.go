@@ -0,0 +1,247 @@
+package dao
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
+)
+
+var InvalidSnapshotKeepError = errors.New("the snapshots to keep should be positive")
+
+const (
+	// metad names the snapshots with the create time, e.g. SNAPSHOT_2021_10_26_12_00_00
+	snapshotNamePrefix     = "SNAPSHOT_"
+	snapshotNameTimeLayout = "2006_01_02_15_04_05"
+)
+
+type Snapshot struct {
+	Name   string   `json:"name"`
+	Status string   `json:"status"`
+	Hosts  []string `json:"hosts"`
+}
+
+type HostBackup struct {
+	Host        string   `json:"host"`
+	Checkpoints []string `json:"checkpoints"`
+}
+
+type SpaceBackup struct {
+	ID    int32        `json:"id"`
+	Name  string       `json:"name"`
+	Hosts []HostBackup `json:"hosts"`
+}
+
+type Backup struct {
+	Name       string        `json:"name"`
+	MetaFiles  []string      `json:"metaFiles"`
+	Full       bool          `json:"full"`
+	AllSpaces  bool          `json:"allSpaces"`
+	CreateTime int64         `json:"createTime"`
+	Spaces     []SpaceBackup `json:"spaces"`
+}
+
+/*
+`ListSnapshots` lists the snapshots from the oldest to the newest by the create time in their names,
+the ones not named by metad are listed first by name
+*/
+func ListSnapshots(nsid string) ([]Snapshot, error) {
+	var snapshots []Snapshot
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) (err error) {
+		snapshots, err = listSnapshots(metaClient)
+		return err
+	})
+	return snapshots, err
+}
+
+/*
+`CreateSnapshot` creates a snapshot of all the spaces and returns it,
+the name is generated by metad with the create time, e.g. SNAPSHOT_2021_10_26_12_00_00
+*/
+func CreateSnapshot(nsid string) (*Snapshot, error) {
+	var created *Snapshot
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		before, err := listSnapshots(metaClient)
+		if err != nil {
+			return err
+		}
+		resp, err := metaClient.CreateSnapshot()
+		if err != nil {
+			return err
+		}
+		if err := metaCodeError(resp, "create snapshot"); err != nil {
+			return err
+		}
+		after, err := listSnapshots(metaClient)
+		if err != nil {
+			return err
+		}
+
+		existed := make(map[string]bool, len(before))
+		for _, snapshot := range before {
+			existed[snapshot.Name] = true
+		}
+		for i := len(after) - 1; i >= 0; i-- {
+			if !existed[after[i].Name] {
+				created = &after[i]
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func DropSnapshot(nsid string, name string) error {
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		return dropSnapshot(metaClient, name)
+	})
+}
+
+/*
+`PruneSnapshots` drops the valid snapshots except the newest keep ones and all the invalid ones,
+and returns the dropped. The valid ones not named by metad are kept, since their create time is unknown.
+*/
+func PruneSnapshots(nsid string, keep int) ([]string, error) {
+	if keep <= 0 {
+		return nil, InvalidSnapshotKeepError
+	}
+	dropped := make([]string, 0)
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		snapshots, err := listSnapshots(metaClient)
+		if err != nil {
+			return err
+		}
+		for _, name := range snapshotsToPrune(snapshots, keep) {
+			if err := dropSnapshot(metaClient, name); err != nil {
+				return err
+			}
+			dropped = append(dropped, name)
+		}
+		return nil
+	})
+	return dropped, err
+}
+
+// snapshotsToPrune picks the snapshots to drop from the ones sorted by listSnapshots
+func snapshotsToPrune(snapshots []Snapshot, keep int) []string {
+	names := make([]string, 0)
+	valid := make([]string, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		if snapshot.Status == string(types.SnapshotStatusInvalid) {
+			names = append(names, snapshot.Name)
+			continue
+		}
+		if snapshot.Status == string(types.SnapshotStatusValid) && !snapshotTime(snapshot.Name).IsZero() {
+			valid = append(valid, snapshot.Name)
+		}
+	}
+	for i := 0; i < len(valid)-keep; i++ {
+		names = append(names, valid[i])
+	}
+	return names
+}
+
+// CreateBackup backs up the spaces, or all of them if spaces is empty, it's supported since 3.0
+func CreateBackup(nsid string, spaces []string) (*Backup, error) {
+	var backup *Backup
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.CreateBackup(spaces)
+		if err != nil {
+			return err
+		}
+		if err := metaCodeError(resp, "create backup"); err != nil {
+			return err
+		}
+		backup = convertBackup(resp.GetBackup())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return backup, nil
+}
+
+func listSnapshots(metaClient nebula.MetaClient) ([]Snapshot, error) {
+	resp, err := metaClient.ListSnapshots()
+	if err != nil {
+		return nil, err
+	}
+	if err := metaCodeError(resp, "list snapshots"); err != nil {
+		return nil, err
+	}
+	snapshots := make([]Snapshot, 0, len(resp.GetSnapshots()))
+	for _, snapshot := range resp.GetSnapshots() {
+		snapshots = append(snapshots, Snapshot{
+			Name:   snapshot.Name,
+			Status: string(snapshot.Status),
+			Hosts:  snapshot.Hosts,
+		})
+	}
+	sortSnapshots(snapshots)
+	return snapshots, nil
+}
+
+// sortSnapshots sorts the snapshots by the create time in their names, and then by name
+func sortSnapshots(snapshots []Snapshot) {
+	sort.Slice(snapshots, func(i, j int) bool {
+		ti, tj := snapshotTime(snapshots[i].Name), snapshotTime(snapshots[j].Name)
+		if ti.Equal(tj) {
+			return snapshots[i].Name < snapshots[j].Name
+		}
+		return ti.Before(tj)
+	})
+}
+
+// snapshotTime parses the create time in the name given by metad, it's zero if the name is not in that form
+func snapshotTime(name string) time.Time {
+	if !strings.HasPrefix(name, snapshotNamePrefix) {
+		return time.Time{}
+	}
+	t, err := time.Parse(snapshotNameTimeLayout, strings.TrimPrefix(name, snapshotNamePrefix))
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func dropSnapshot(metaClient nebula.MetaClient, name string) error {
+	resp, err := metaClient.DropSnapshot(name)
+	if err != nil {
+		return err
+	}
+	return metaCodeError(resp, "drop snapshot "+name)
+}
+
+func convertBackup(backup types.BackupDesc) *Backup {
+	result := &Backup{
+		Name:       backup.Name,
+		MetaFiles:  backup.MetaFiles,
+		Full:       backup.Full,
+		AllSpaces:  backup.AllSpaces,
+		CreateTime: backup.CreateTime,
+		Spaces:     make([]SpaceBackup, 0, len(backup.Spaces)),
+	}
+	for _, space := range backup.Spaces {
+		spaceBackup := SpaceBackup{
+			ID:    space.ID,
+			Name:  space.Name,
+			Hosts: make([]HostBackup, 0, len(space.Hosts)),
+		}
+		for _, host := range space.Hosts {
+			spaceBackup.Hosts = append(spaceBackup.Hosts, HostBackup{
+				Host:        fmt.Sprintf("%s:%d", host.Host.Host, host.Host.Port),
+				Checkpoints: host.Checkpoints,
+			})
+		}
+		result.Spaces = append(result.Spaces, spaceBackup)
+	}
+	return result
+}
@@ -0,0 +1,97 @@
+package dao
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSnapshotsToPrune(t *testing.T) {
+	snapshot := func(name string, status string) Snapshot {
+		return Snapshot{Name: name, Status: status}
+	}
+
+	cases := []struct {
+		name      string
+		snapshots []Snapshot
+		keep      int
+		expect    []string
+	}{
+		{
+			name:   "empty",
+			keep:   1,
+			expect: []string{},
+		},
+		{
+			name: "fewer than keep",
+			snapshots: []Snapshot{
+				snapshot("SNAPSHOT_2021_10_26_12_00_00", "VALID"),
+			},
+			keep:   2,
+			expect: []string{},
+		},
+		{
+			name: "valid",
+			snapshots: []Snapshot{
+				snapshot("SNAPSHOT_2021_10_26_12_00_00", "VALID"),
+				snapshot("SNAPSHOT_2021_10_27_12_00_00", "VALID"),
+				snapshot("SNAPSHOT_2021_10_28_12_00_00", "VALID"),
+			},
+			keep:   2,
+			expect: []string{"SNAPSHOT_2021_10_26_12_00_00"},
+		},
+		{
+			name: "invalid not counted",
+			snapshots: []Snapshot{
+				snapshot("SNAPSHOT_2021_10_26_12_00_00", "VALID"),
+				snapshot("SNAPSHOT_2021_10_27_12_00_00", "INVALID"),
+				snapshot("SNAPSHOT_2021_10_28_12_00_00", "VALID"),
+				snapshot("SNAPSHOT_2021_10_29_12_00_00", "INVALID"),
+			},
+			keep:   2,
+			expect: []string{"SNAPSHOT_2021_10_27_12_00_00", "SNAPSHOT_2021_10_29_12_00_00"},
+		},
+		{
+			name: "not named by metad",
+			snapshots: []Snapshot{
+				snapshot("manual", "VALID"),
+				snapshot("broken", "INVALID"),
+				snapshot("SNAPSHOT_2021_10_26_12_00_00", "VALID"),
+				snapshot("SNAPSHOT_2021_10_27_12_00_00", "VALID"),
+			},
+			keep:   1,
+			expect: []string{"broken", "SNAPSHOT_2021_10_26_12_00_00"},
+		},
+	}
+
+	for _, tc := range cases {
+		if names := snapshotsToPrune(tc.snapshots, tc.keep); !reflect.DeepEqual(names, tc.expect) {
+			t.Errorf("%s: got %v, want %v", tc.name, names, tc.expect)
+		}
+	}
+}
+
+func TestSortSnapshots(t *testing.T) {
+	snapshots := []Snapshot{
+		{Name: "SNAPSHOT_2021_11_02_08_00_00"},
+		{Name: "manual"},
+		{Name: "SNAPSHOT_2021_10_26_12_00_00"},
+		{Name: "SNAPSHOT_2021_10_26_09_30_00"},
+		{Name: "SNAPSHOT_bad"},
+	}
+	sortSnapshots(snapshots)
+
+	names := make([]string, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		names = append(names, snapshot.Name)
+	}
+	expect := []string{
+		"SNAPSHOT_bad",
+		"manual",
+		"SNAPSHOT_2021_10_26_09_30_00",
+		"SNAPSHOT_2021_10_26_12_00_00",
+		"SNAPSHOT_2021_11_02_08_00_00",
+	}
+	if !reflect.DeepEqual(names, expect) {
+		t.Errorf("got %v, want %v", names, expect)
+	}
+}
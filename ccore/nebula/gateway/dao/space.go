@@ -0,0 +1,117 @@
+package dao
+
+import (
+	"strings"
+
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
+)
+
+/*
+`SpaceDesc` is the properties to create a space, the unset ones are the defaults of nebula,
+VidType is FIXED_STRING or INT64, and Zones are supported since 3.0
+*/
+type SpaceDesc struct {
+	Name          string   `json:"name"`
+	PartitionNum  int32    `json:"partitionNum"`
+	ReplicaFactor int32    `json:"replicaFactor"`
+	VidType       string   `json:"vidType"`
+	VidLength     int16    `json:"vidLength"`
+	Charset       string   `json:"charset"`
+	Collate       string   `json:"collate"`
+	Zones         []string `json:"zones"`
+	Comment       string   `json:"comment"`
+}
+
+type SpaceDetail struct {
+	ID int32 `json:"id"`
+	SpaceDesc
+}
+
+func GetSpace(nsid string, space string) (*SpaceDetail, error) {
+	var detail SpaceDetail
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.GetSpace(space)
+		if err != nil {
+			return err
+		}
+		if err := metaCodeError(resp, "get space "+space); err != nil {
+			return err
+		}
+		item := resp.GetSpace()
+		detail = SpaceDetail{
+			ID: item.ID,
+			SpaceDesc: SpaceDesc{
+				Name:          item.Desc.Name,
+				PartitionNum:  item.Desc.PartitionNum,
+				ReplicaFactor: item.Desc.ReplicaFactor,
+				VidType:       string(item.Desc.VidType),
+				VidLength:     item.Desc.VidLength,
+				Charset:       item.Desc.Charset,
+				Collate:       item.Desc.Collate,
+				Zones:         item.Desc.Zones,
+				Comment:       item.Desc.Comment,
+			},
+		}
+		if detail.Zones == nil {
+			detail.Zones = make([]string, 0)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &detail, nil
+}
+
+func CreateSpace(nsid string, desc SpaceDesc, ifNotExists bool) error {
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.CreateSpace(types.SpaceDesc{
+			Name:          desc.Name,
+			PartitionNum:  desc.PartitionNum,
+			ReplicaFactor: desc.ReplicaFactor,
+			VidType:       types.PropertyType(strings.ToUpper(desc.VidType)),
+			VidLength:     desc.VidLength,
+			Charset:       desc.Charset,
+			Collate:       desc.Collate,
+			Zones:         desc.Zones,
+			Comment:       desc.Comment,
+		}, ifNotExists)
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "create space "+desc.Name)
+	})
+}
+
+// CloneSpace creates newSpace with the schemas of space but without the data, it's supported since 2.6
+func CloneSpace(nsid string, space string, newSpace string) error {
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.CreateSpaceAs(space, newSpace)
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "create space "+newSpace+" as "+space)
+	})
+}
+
+func DropSpace(nsid string, space string, ifExists bool) error {
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.DropSpace(space, ifExists)
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "drop space "+space)
+	})
+}
+
+// AddSpaceZones adds the zones to place the partitions of space, it's supported since 3.0
+func AddSpaceZones(nsid string, space string, zones []string) error {
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.AlterSpace(space, types.AlterSpaceAddZone, zones)
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "add zones into space "+space)
+	})
+}
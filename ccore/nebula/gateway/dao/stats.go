@@ -0,0 +1,107 @@
+package dao
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
+)
+
+type Correlativity struct {
+	PartID     int32   `json:"partId"`
+	Proportion float64 `json:"proportion"`
+}
+
+/*
+`SpaceStats` is the result of the last STATS job of a space, Tags and Edges are the counts of each tag and edge type,
+the correlativities map each partition to the partitions connected by its out (positive) or in (negative) edges
+*/
+type SpaceStats struct {
+	Space                     string                    `json:"space"`
+	Status                    string                    `json:"status"`
+	Tags                      map[string]int64          `json:"tags"`
+	Edges                     map[string]int64          `json:"edges"`
+	SpaceVertices             int64                     `json:"spaceVertices"`
+	SpaceEdges                int64                     `json:"spaceEdges"`
+	PositivePartCorrelativity map[int32][]Correlativity `json:"positivePartCorrelativity"`
+	NegativePartCorrelativity map[int32][]Correlativity `json:"negativePartCorrelativity"`
+}
+
+// GetStats gets the result of the last STATS job of space, it fails if the job has never been submitted
+func GetStats(nsid string, space string) (*SpaceStats, error) {
+	var stats *SpaceStats
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.GetStats(space)
+		if err != nil {
+			return err
+		}
+		if err := metaCodeError(resp, "get stats of "+space); err != nil {
+			return err
+		}
+		stats = convertStats(space, resp.GetStats())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+/*
+`CollectStats` submits a STATS job of space and waits for it to finish in timeout,
+then gets the stats, it fails if the job is failed or stopped
+*/
+func CollectStats(nsid string, space string, timeout time.Duration) (*SpaceStats, error) {
+	id, err := SubmitJob(nsid, space, string(types.JobStats), nil)
+	if err != nil {
+		return nil, err
+	}
+	detail, err := WaitJob(nsid, space, id, timeout, 0)
+	if err != nil {
+		return nil, err
+	}
+	if detail.Status != string(types.JobStatusFinished) {
+		return nil, fmt.Errorf("stats job %d of %s is %s", id, space, detail.Status)
+	}
+	return GetStats(nsid, space)
+}
+
+func convertStats(space string, item types.StatsItem) *SpaceStats {
+	stats := &SpaceStats{
+		Space:                     space,
+		Status:                    string(item.Status),
+		Tags:                      item.TagVertices,
+		Edges:                     item.Edges,
+		SpaceVertices:             item.SpaceVertices,
+		SpaceEdges:                item.SpaceEdges,
+		PositivePartCorrelativity: convertCorrelativities(item.PositivePartCorrelativity),
+		NegativePartCorrelativity: convertCorrelativities(item.NegativePartCorrelativity),
+	}
+	if stats.Tags == nil {
+		stats.Tags = make(map[string]int64)
+	}
+	if stats.Edges == nil {
+		stats.Edges = make(map[string]int64)
+	}
+	return stats
+}
+
+func convertCorrelativities(parts map[int32][]types.Correlativity) map[int32][]Correlativity {
+	correlativities := make(map[int32][]Correlativity, len(parts))
+	for partID, items := range parts {
+		list := make([]Correlativity, 0, len(items))
+		for _, item := range items {
+			list = append(list, Correlativity{
+				PartID:     item.PartID,
+				Proportion: item.Proportion,
+			})
+		}
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].PartID < list[j].PartID
+		})
+		correlativities[partID] = list
+	}
+	return correlativities
+}
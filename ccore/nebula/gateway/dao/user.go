@@ -0,0 +1,199 @@
+package dao
+
+import (
+	"errors"
+
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/gateway/pool"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
+)
+
+var NotSessionAccountError = errors.New("only the password of the account of the session can be changed with the old one")
+
+type Role struct {
+	Account string `json:"account"`
+	// Space is empty for the GOD role, which is not in any space
+	Space string `json:"space"`
+	Role  string `json:"role"`
+}
+
+type User struct {
+	Account string `json:"account"`
+	Roles   []Role `json:"roles"`
+}
+
+// ListUsers lists the users with their roles in every space
+func ListUsers(nsid string) ([]User, error) {
+	users := make([]User, 0)
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.ListUsers()
+		if err != nil {
+			return err
+		}
+		if err := metaCodeError(resp, "list users"); err != nil {
+			return err
+		}
+		spaceNames, err := getSpaceNames(metaClient)
+		if err != nil {
+			return err
+		}
+		for _, account := range resp.GetUsers() {
+			roles, err := getUserRoles(metaClient, account, spaceNames)
+			if err != nil {
+				return err
+			}
+			users = append(users, User{
+				Account: account,
+				Roles:   roles,
+			})
+		}
+		return nil
+	})
+	return users, err
+}
+
+func GetUserRoles(nsid string, account string) ([]Role, error) {
+	var roles []Role
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		spaceNames, err := getSpaceNames(metaClient)
+		if err != nil {
+			return err
+		}
+		roles, err = getUserRoles(metaClient, account, spaceNames)
+		return err
+	})
+	return roles, err
+}
+
+func ListRoles(nsid string, space string) ([]Role, error) {
+	roles := make([]Role, 0)
+	err := adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.ListRoles(space)
+		if err != nil {
+			return err
+		}
+		if err := metaCodeError(resp, "list roles of "+space); err != nil {
+			return err
+		}
+		for _, role := range resp.GetRoles() {
+			roles = append(roles, Role{
+				Account: role.Account,
+				Space:   space,
+				Role:    string(role.Role),
+			})
+		}
+		return nil
+	})
+	return roles, err
+}
+
+func CreateUser(nsid string, account string, password string, ifNotExists bool) error {
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.CreateUser(account, password, ifNotExists)
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "create user "+account)
+	})
+}
+
+func DropUser(nsid string, account string, ifExists bool) error {
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.DropUser(account, ifExists)
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "drop user "+account)
+	})
+}
+
+// AlterUser resets the password of account without the old one
+func AlterUser(nsid string, account string, password string) error {
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.AlterUser(account, password)
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "alter user "+account)
+	})
+}
+
+/*
+`ChangePassword` changes the password of the account of the session with the old one,
+it doesn't require the God role, but the other accounts can't be changed.
+The session keeps using the new password to reconnect and to be restored
+*/
+func ChangePassword(nsid string, account string, newPassword string, oldPassword string) error {
+	client, err := pool.GetClient(nsid)
+	if err != nil {
+		return err
+	}
+	if client.Username() != account {
+		return NotSessionAccountError
+	}
+	err = client.MetaDo(func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.ChangePassword(account, newPassword, oldPassword)
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "change password of "+account)
+	})
+	if err != nil {
+		return err
+	}
+	return client.UpdatePassword(newPassword)
+}
+
+func GrantRole(nsid string, account string, space string, role string) error {
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.GrantRole(account, space, types.RoleType(role))
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "grant role "+role+" on "+space+" to "+account)
+	})
+}
+
+func RevokeRole(nsid string, account string, space string, role string) error {
+	return adminDo(nsid, func(metaClient nebula.MetaClient) error {
+		resp, err := metaClient.RevokeRole(account, space, types.RoleType(role))
+		if err != nil {
+			return err
+		}
+		return metaCodeError(resp, "revoke role "+role+" on "+space+" from "+account)
+	})
+}
+
+func getUserRoles(metaClient nebula.MetaClient, account string, spaceNames map[int32]string) ([]Role, error) {
+	resp, err := metaClient.GetUserRoles(account)
+	if err != nil {
+		return nil, err
+	}
+	if err := metaCodeError(resp, "get roles of "+account); err != nil {
+		return nil, err
+	}
+	roles := make([]Role, 0, len(resp.GetRoles()))
+	for _, role := range resp.GetRoles() {
+		roles = append(roles, Role{
+			Account: account,
+			Space:   spaceNames[role.SpaceID],
+			Role:    string(role.Role),
+		})
+	}
+	return roles, nil
+}
+
+func getSpaceNames(metaClient nebula.MetaClient) (map[int32]string, error) {
+	resp, err := metaClient.ListSpaces()
+	if err != nil {
+		return nil, err
+	}
+	if err := metaCodeError(resp, "list spaces"); err != nil {
+		return nil, err
+	}
+	spaceNames := make(map[int32]string, len(resp.GetSpaces()))
+	for _, space := range resp.GetSpaces() {
+		spaceNames[space.GetId()] = space.GetName()
+	}
+	return spaceNames, nil
+}
@@ -0,0 +1,233 @@
+package pool
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
+)
+
+var (
+	// configuredMetaEndpoints are the meta hosts set by the server, see SetMetaEndpoints
+	configuredMetaEndpoints []string
+
+	NoMetaHostsError        = errors.New("no meta hosts are shown by graphd")
+	NotGodError             = errors.New("the admin requests require the God role")
+	UntrustedMetaHostsError = errors.New("the meta hosts are not the ones of the cluster")
+)
+
+/*
+`SetMetaEndpoints` sets the meta hosts of the cluster for all the clients,
+the meta hosts given on connecting must be some of them,
+and the ones shown by graphd are not used
+*/
+func SetMetaEndpoints(endpoints []string) {
+	configuredMetaEndpoints = endpoints
+}
+
+/*
+`MetaDo` runs fn with the meta client of the session, the meta client is opened on first use
+with the meta hosts shown by graphd, and the calls of a session are serialized
+*/
+func (client *Client) MetaDo(fn func(metaClient nebula.MetaClient) error) error {
+	client.metaMux.Lock()
+	defer client.metaMux.Unlock()
+
+	if client.metaClient == nil {
+		if err := client.openMetaClient(); err != nil {
+			return err
+		}
+	}
+	return fn(client.metaClient)
+}
+
+/*
+`AdminDo` runs fn with the meta client like `MetaDo`,
+but only for the accounts with the God role, which is checked with graphd on every call
+since the role can be revoked during the session
+*/
+func (client *Client) AdminDo(fn func(metaClient nebula.MetaClient) error) error {
+	if err := client.checkGod(); err != nil {
+		return err
+	}
+	return client.MetaDo(fn)
+}
+
+/*
+`SpaceDo` runs fn with the meta client like `MetaDo`,
+but only if the account has a role on space, which is checked with graphd on every call
+*/
+func (client *Client) SpaceDo(space string, fn func(metaClient nebula.MetaClient) error) error {
+	if err := client.checkSpace(space); err != nil {
+		return err
+	}
+	return client.MetaDo(fn)
+}
+
+func (client *Client) checkSpace(space string) error {
+	// graphd only describes the spaces the account has a role on
+	response, err := client.executeInternal("DESCRIBE SPACE `" + strings.ReplaceAll(space, "`", "\\`") + "`")
+	if err != nil {
+		return err
+	}
+	if !response.Result.IsSucceed() {
+		return errors.New(response.Result.GetErrorMsg())
+	}
+	return nil
+}
+
+func (client *Client) checkGod() error {
+	// only the God role can show the users
+	response, err := client.executeInternal("SHOW USERS")
+	if err != nil {
+		return err
+	}
+	if !response.Result.IsSucceed() {
+		return NotGodError
+	}
+	return nil
+}
+
+func (client *Client) Factory() nebula.Factory {
+	return client.graphClients[0].Factory()
+}
+
+// Username is the account the session is authenticated with
+func (client *Client) Username() string {
+	return client.account.username
+}
+
+func (client *Client) password() string {
+	client.accountMux.RLock()
+	defer client.accountMux.RUnlock()
+	return client.account.password
+}
+
+/*
+`UpdatePassword` sets the password of the session account after it's changed,
+so the reconnections, the stored session and the sessions to kill queries authenticate with the new one
+*/
+func (client *Client) UpdatePassword(password string) error {
+	client.accountMux.Lock()
+	client.account.password = password
+	client.accountMux.Unlock()
+	for _, graphClient := range client.graphClients {
+		graphClient.SetPassword(password)
+	}
+	return client.save()
+}
+
+func (client *Client) TimezoneInfo() types.TimezoneInfo {
+	return client.timezone
+}
+
+func (client *Client) openMetaClient() error {
+	endpoints, err := client.trustedMetaEndpoints()
+	if err != nil {
+		return err
+	}
+
+	opts := append([]nebula.Option{}, client.opts...)
+	opts = append(opts, nebula.WithVersion(client.graphClients[0].Version()))
+	metaClient, err := nebula.NewMetaClient(endpoints, opts...)
+	if err != nil {
+		return err
+	}
+	if err := metaClient.Open(); err != nil {
+		return err
+	}
+	client.metaClient = metaClient
+	return nil
+}
+
+/*
+`trustedMetaEndpoints` returns the meta hosts given on connecting if they are trusted,
+or all the trusted ones if none is given.
+The trusted ones are the configured ones if `SetMetaEndpoints` is called,
+otherwise they are shown by the graphd the account is authenticated with
+*/
+func (client *Client) trustedMetaEndpoints() ([]string, error) {
+	trusted := configuredMetaEndpoints
+	if len(trusted) == 0 {
+		var err error
+		if trusted, err = client.showMetaHosts(); err != nil {
+			return nil, err
+		}
+	}
+	if len(client.metaEndpoints) == 0 {
+		return trusted, nil
+	}
+
+	for _, endpoint := range client.metaEndpoints {
+		found := false
+		for _, t := range trusted {
+			if endpoint == t {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, UntrustedMetaHostsError
+		}
+	}
+	return client.metaEndpoints, nil
+}
+
+func (client *Client) closeMetaClient() {
+	client.metaMux.Lock()
+	defer client.metaMux.Unlock()
+
+	if client.metaClient != nil {
+		_ = client.metaClient.Close()
+		client.metaClient = nil
+	}
+}
+
+// executeInternal executes gql with the session for the gateway itself
+func (client *Client) executeInternal(gql string) (ChannelResponse, error) {
+	responseChannel := make(chan ChannelResponse, 1)
+	client.RequestChannel <- ChannelRequest{
+		Gql:             gql,
+		ResponseChannel: responseChannel,
+	}
+	response := <-responseChannel
+	return response, response.Error
+}
+
+func (client *Client) showMetaHosts() ([]string, error) {
+	response, err := client.executeInternal("SHOW HOSTS META")
+	if err != nil {
+		return nil, err
+	}
+	if !response.Result.IsSucceed() {
+		return nil, errors.New(response.Result.GetErrorMsg())
+	}
+
+	hosts, err := response.Result.GetValuesByColName("Host")
+	if err != nil {
+		return nil, err
+	}
+	ports, err := response.Result.GetValuesByColName("Port")
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := make([]string, 0, len(hosts))
+	for i := range hosts {
+		host, err := hosts[i].AsString()
+		if err != nil {
+			return nil, err
+		}
+		port, err := ports[i].AsInt()
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, fmt.Sprintf("%s:%d", host, port))
+	}
+	if len(endpoints) == 0 {
+		return nil, NoMetaHostsError
+	}
+	return endpoints, nil
+}
@@ -0,0 +1,84 @@
+package pool
+
+import (
+	"testing"
+
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
+)
+
+// fakeGraphClient records the password set, the other methods are not used
+type fakeGraphClient struct {
+	nebula.GraphClient
+	password string
+}
+
+func (c *fakeGraphClient) Version() nebula.Version             { return nebula.Version3_0 }
+func (c *fakeGraphClient) GetTimezoneInfo() types.TimezoneInfo { return types.TimezoneInfo{} }
+func (c *fakeGraphClient) SetPassword(password string)         { c.password = password }
+
+type memoryStore struct {
+	sessions map[string]StoredSession
+}
+
+func (s *memoryStore) Save(session *StoredSession) error {
+	s.sessions[session.Nsid] = *session
+	return nil
+}
+
+func (s *memoryStore) Load(nsid string) (*StoredSession, bool, error) {
+	session, ok := s.sessions[nsid]
+	return &session, ok, nil
+}
+
+func (s *memoryStore) Delete(nsid string) error {
+	delete(s.sessions, nsid)
+	return nil
+}
+
+func (s *memoryStore) DeleteExpired(updatedBefore int64) error {
+	return nil
+}
+
+func TestUpdatePassword(t *testing.T) {
+	defer func(store SessionStore, key []byte) {
+		sessionStore, sessionKey = store, key
+	}(sessionStore, sessionKey)
+	store := &memoryStore{sessions: make(map[string]StoredSession)}
+	if err := SetSessionStore(store, "secret"); err != nil {
+		t.Fatal(err)
+	}
+
+	graphClients := []*fakeGraphClient{{password: "old"}, {password: "old"}}
+	client := newClient("nsid", []nebula.GraphClient{graphClients[0], graphClients[1]}, []string{"graphd:9669"}, "user", "old")
+	if err := client.save(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.UpdatePassword("new"); err != nil {
+		t.Fatal(err)
+	}
+	if password := client.password(); password != "new" {
+		t.Errorf("got password %q, want %q", password, "new")
+	}
+	for i, graphClient := range graphClients {
+		if graphClient.password != "new" {
+			t.Errorf("graph client %d: got password %q, want %q", i, graphClient.password, "new")
+		}
+	}
+
+	session, ok, _ := store.Load("nsid")
+	if !ok {
+		t.Fatal("the session is not stored")
+	}
+	password, err := decryptPassword(session.EncryptedPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if password != "new" {
+		t.Errorf("got stored password %q, want %q", password, "new")
+	}
+	if session.Username != "user" {
+		t.Errorf("got stored username %q, want %q", session.Username, "user")
+	}
+}
@@ -8,11 +8,13 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/facebook/fbthrift/thrift/lib/go/thrift"
 	uuid "github.com/satori/go.uuid"
 	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula"
+	nerrors "github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/errors"
 	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
 	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/wrapper"
 )
@@ -21,6 +23,8 @@ var (
 	ConnectionClosedError = errors.New("an existing connection was forcibly closed, please check your network")
 	SessionLostError      = errors.New("the connection session was lost, please connect again")
 	InterruptError        = errors.New("other statements was not executed due to this error")
+	NotExecutedError      = errors.New("the statement was not executed due to a previous error")
+	ExecutionTimeoutError = errors.New("the execution timed out and was cancelled")
 )
 
 // Console side commands
@@ -30,7 +34,11 @@ const (
 	Params                       = 2
 	clientRecycleNum             = 30
 	clientMaxNum                 = 200
+	sessionMaxWidth              = 16
 	SessionExpiredDuration int64 = 3600
+	// the session ids of the tracked requests which are not taken by a graphd session yet
+	notSentSessionID int64 = -1
+	cancelSessionID  int64 = -2
 )
 
 type Account struct {
@@ -51,14 +59,41 @@ type ChannelRequest struct {
 	ParamList       types.ParameterList
 }
 
+type BatchItem struct {
+	Gql       string
+	ParamList types.ParameterList
+}
+
+type BatchChannelRequest struct {
+	Items           []BatchItem
+	StopOnError     bool
+	ResponseChannel chan []ChannelResponse
+}
+
 type Client struct {
-	graphClient    nebula.GraphClient
-	RequestChannel chan ChannelRequest
-	CloseChannel   chan bool
-	updateTime     int64
-	parameterMap   types.ParameterMap
-	account        *Account
-	timezone       types.TimezoneInfo
+	nsid string
+	// graphClients are the graphd sessions serving the requests in parallel
+	graphClients        []nebula.GraphClient
+	endpoints           []string
+	opts                []nebula.Option
+	RequestChannel      chan ChannelRequest
+	BatchRequestChannel chan BatchChannelRequest
+	CloseChannel        chan bool
+	updateTime          int64
+	storeTime           int64
+	parameterMap        types.ParameterMap
+	parameterMux        sync.RWMutex
+	account             *Account
+	// accountMux guards the password of account, which can be changed during the session
+	accountMux sync.RWMutex
+	timezone   types.TimezoneInfo
+	// running maps the response channel of a running or tracked request to its graphd session id
+	running    map[chan ChannelResponse]int64
+	runningMux sync.Mutex
+	// metaClient is opened on the first meta request
+	metaClient    nebula.MetaClient
+	metaEndpoints []string
+	metaMux       sync.Mutex
 }
 
 type ClientInfo struct {
@@ -70,8 +105,10 @@ var (
 	clientPool       = make(map[string]*Client)
 	currentClientNum = 0
 	clientMux        sync.Mutex
+	sessionWidth     = 1
 
 	ClientNotExistedError = errors.New("get client error: client not existed, session expired")
+	NoIdleConnectionError = errors.New("There is no idle connection now, please try it later")
 )
 
 func isThriftProtoError(err error) bool {
@@ -211,66 +248,153 @@ func ListParams(args string, tmpParameter types.ParameterMap, sessionMap types.P
 	return nil
 }
 
+/*
+`SetSessionWidth` sets the number of graphd sessions owned by each new client,
+which is the number of requests a client can execute in parallel
+*/
+func SetSessionWidth(width int) {
+	if width < 1 {
+		width = 1
+	}
+	if width > sessionMaxWidth {
+		width = sessionMaxWidth
+	}
+	sessionWidth = width
+}
+
 func NewClient(address string, port int, username string, password string, opts ...nebula.Option) (*ClientInfo, error) {
+	host := strings.Join([]string{address, strconv.Itoa(port)}, ":")
+	return NewClientWithEndpoints([]string{host}, nil, username, password, opts...)
+}
+
+/*
+`NewClientWithEndpoints` connects to the first available graphd of endpoints,
+the client fails over to the next one when the connection is broken.
+The meta requests use metaEndpoints, or the trusted meta hosts if it's empty,
+see `trustedMetaEndpoints` for the ones which are accepted.
+*/
+func NewClientWithEndpoints(endpoints []string, metaEndpoints []string, username string, password string, opts ...nebula.Option) (*ClientInfo, error) {
 	var err error
 
-	// TODO: it's better to add a schedule to make it instead
-	if currentClientNum > clientRecycleNum {
-		go recycleClients()
-		if currentClientNum >= clientMaxNum {
-			return nil, errors.New("There is no idle connection now, please try it later")
-		}
+	if err := checkClientNum(); err != nil {
+		return nil, err
 	}
 
-	host := strings.Join([]string{address, strconv.Itoa(port)}, ":")
-	c, err := nebula.NewGraphClient([]string{host}, username, password, opts...)
+	graphClients, err := openGraphClients(endpoints, username, password, sessionWidth, opts...)
 	if err != nil {
 		return nil, err
 	}
-	if err := c.Open(); err != nil {
-		return nil, err
-	}
 
 	u, err := uuid.NewV4()
 	if err != nil {
+		closeGraphClients(graphClients)
 		return nil, err
 	}
 
 	nsid := u.String()
-	ver := c.Version()
-
-	client := &Client{
-		graphClient:    c,
-		RequestChannel: make(chan ChannelRequest),
-		CloseChannel:   make(chan bool),
-		updateTime:     time.Now().Unix(),
-		parameterMap:   make(types.ParameterMap),
+	client := newClient(nsid, graphClients, endpoints, username, password, opts...)
+	client.metaEndpoints = metaEndpoints
+	if err := client.save(); err != nil {
+		closeGraphClients(graphClients)
+		return nil, err
+	}
+	registerClient(client)
+	if len(metaEndpoints) > 0 {
+		// reject the untrusted ones early, they are checked again when the meta client is opened
+		if _, err := client.trustedMetaEndpoints(); err != nil {
+			client.CloseChannel <- true
+			return nil, err
+		}
+	}
+
+	info := &ClientInfo{
+		ClientID:      nsid,
+		NebulaVersion: client.graphClients[0].Version(),
+	}
+	return info, err
+}
+
+func newClient(nsid string, graphClients []nebula.GraphClient, endpoints []string, username string, password string, opts ...nebula.Option) *Client {
+	return &Client{
+		nsid:                nsid,
+		graphClients:        graphClients,
+		endpoints:           endpoints,
+		opts:                opts,
+		RequestChannel:      make(chan ChannelRequest),
+		BatchRequestChannel: make(chan BatchChannelRequest),
+		CloseChannel:        make(chan bool),
+		updateTime:          time.Now().Unix(),
+		parameterMap:        make(types.ParameterMap),
 		account: &Account{
 			username: username,
 			password: password,
 		},
-		timezone: c.GetTimezoneInfo(),
+		timezone: graphClients[0].GetTimezoneInfo(),
+		running:  make(map[chan ChannelResponse]int64),
 	}
+}
 
+func registerClient(client *Client) {
 	clientMux.Lock()
-	clientPool[nsid] = client
+	clientPool[client.nsid] = client
 	currentClientNum++
 	clientMux.Unlock()
 
 	// Make a goroutine to deal with concurrent requests from each connection
-	go handleRequest(nsid)
+	go handleRequest(client.nsid)
+}
 
-	info := &ClientInfo{
-		ClientID:      nsid,
-		NebulaVersion: ver,
+/*
+`openGraphClients` opens width graphd sessions,
+all of them with the same version as the first one
+*/
+func openGraphClients(endpoints []string, username string, password string, width int, opts ...nebula.Option) ([]nebula.GraphClient, error) {
+	graphClients := make([]nebula.GraphClient, 0, width)
+	for i := 0; i < width; i++ {
+		c, err := nebula.NewGraphClient(endpoints, username, password, opts...)
+		if err != nil {
+			closeGraphClients(graphClients)
+			return nil, err
+		}
+		if err := c.Open(); err != nil {
+			closeGraphClients(graphClients)
+			return nil, err
+		}
+		if i == 0 {
+			opts = append(append([]nebula.Option{}, opts...), nebula.WithVersion(c.Version()))
+		}
+		graphClients = append(graphClients, c)
+	}
+	return graphClients, nil
+}
+
+func closeGraphClients(graphClients []nebula.GraphClient) {
+	for _, c := range graphClients {
+		_ = c.Close()
 	}
-	return info, err
 }
 
 func ClearClients() {
 	for _, client := range clientPool {
-		client.graphClient.Close()
+		client.closeMetaClient()
+		closeGraphClients(client.graphClients)
+	}
+}
+
+// checkClientNum recycles the expired clients if there are many, and fails if the pool is full
+func checkClientNum() error {
+	clientMux.Lock()
+	num := currentClientNum
+	clientMux.Unlock()
+
+	// TODO: it's better to add a schedule to make it instead
+	if num > clientRecycleNum {
+		go recycleClients()
+		if num >= clientMaxNum {
+			return NoIdleConnectionError
+		}
 	}
+	return nil
 }
 
 func recycleClients() {
@@ -286,86 +410,280 @@ func recycleClients() {
 }
 
 func handleRequest(nsid string) {
-	var err error
 	client := clientPool[nsid]
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for _, graphClient := range client.graphClients {
+		wg.Add(1)
+		go func(graphClient nebula.GraphClient) {
+			defer wg.Done()
+			client.serve(graphClient, done)
+		}(graphClient)
+	}
+
+	<-client.CloseChannel
+	close(done)
+	// wait for the running requests to finish
+	wg.Wait()
+
+	client.closeMetaClient()
+	clientMux.Lock()
+	closeGraphClients(client.graphClients)
+	currentClientNum--
+	delete(clientPool, nsid)
+	clientMux.Unlock()
+	deleteStoredSession(nsid)
+}
+
+// serve handles the requests with one graphd session until done is closed.
+func (client *Client) serve(graphClient nebula.GraphClient, done chan struct{}) {
 	for {
 		select {
 		case request := <-client.RequestChannel:
-			func() {
-				defer func() {
-					if err := recover(); err != nil {
-						request.ResponseChannel <- ChannelResponse{
-							Result: nil,
-							Msg:    err,
-							Error:  SessionLostError,
-						}
-					}
-				}()
-				showMap := make(types.ParameterMap)
-				if request.ParamList != nil && len(request.ParamList) > 0 {
-					showMap, err = executeCmd(request.ParamList, client.parameterMap)
-					if err != nil {
-						if len(request.Gql) > 0 {
-							err = fmt.Errorf("%s. %s.\n", err.Error(), InterruptError.Error())
-						}
-						request.ResponseChannel <- ChannelResponse{
-							Result: nil,
-							Params: showMap,
-							Error:  err,
-						}
-						return
-					}
-				}
+			if !client.setRunning(request.ResponseChannel, graphClient.GetSessionId()) {
+				// the request was given up before it's sent
+				request.ResponseChannel <- ChannelResponse{Error: ExecutionTimeoutError}
+				continue
+			}
+			response := client.executeRequest(graphClient, request.Gql, request.ParamList)
+			client.UntrackRequest(request.ResponseChannel)
+			request.ResponseChannel <- response
+		case request := <-client.BatchRequestChannel:
+			request.ResponseChannel <- client.executeBatchRequest(graphClient, request)
+		case <-done:
+			return // Exit loop
+		}
+	}
+}
 
-				if len(request.Gql) > 0 {
-					execResponse, err := client.graphClient.ExecuteWithParameter([]byte(request.Gql), client.parameterMap)
-					if err != nil {
-						if isThriftProtoError(err) || isThriftTransportError(err) {
-							err = ConnectionClosedError
-						}
-						request.ResponseChannel <- ChannelResponse{
-							Result: nil,
-							Error:  err,
-						}
-						return
-					}
-
-					res, err := wrapper.GenResultSet(execResponse, client.graphClient.Factory(), client.timezone)
-					if err != nil {
-						err = fmt.Errorf("%s. %s.\n", err.Error(), InterruptError.Error())
-					}
-					request.ResponseChannel <- ChannelResponse{
-						Result: res,
-						Params: showMap,
-						Error:  err,
-					}
-				} else {
-					request.ResponseChannel <- ChannelResponse{
-						Result: nil,
-						Params: showMap,
-						Error:  nil,
-					}
-				}
+/*
+`TrackRequest` records the request of responseChannel before it's sent to RequestChannel,
+so `KillRunningQueries` can cancel it even if no graphd session has taken it yet.
+The caller must call `UntrackRequest` if the request is not sent at all.
+*/
+func (client *Client) TrackRequest(responseChannel chan ChannelResponse) {
+	client.runningMux.Lock()
+	client.running[responseChannel] = notSentSessionID
+	client.runningMux.Unlock()
+}
+
+func (client *Client) UntrackRequest(responseChannel chan ChannelResponse) {
+	client.runningMux.Lock()
+	delete(client.running, responseChannel)
+	client.runningMux.Unlock()
+}
+
+// setRunning records the graphd session of the request, it returns false if the request has been cancelled
+func (client *Client) setRunning(responseChannel chan ChannelResponse, sessionId int64) bool {
+	client.runningMux.Lock()
+	defer client.runningMux.Unlock()
+	if client.running[responseChannel] == cancelSessionID {
+		delete(client.running, responseChannel)
+		return false
+	}
+	client.running[responseChannel] = sessionId
+	return true
+}
+
+func (client *Client) executeRequest(graphClient nebula.GraphClient, gql string, paramList types.ParameterList) (response ChannelResponse) {
+	defer func() {
+		if err := recover(); err != nil {
+			response = ChannelResponse{
+				Result: nil,
+				Msg:    err,
+				Error:  SessionLostError,
+			}
+		}
+	}()
+	var err error
+	showMap := make(types.ParameterMap)
+	if paramList != nil && len(paramList) > 0 {
+		client.parameterMux.Lock()
+		showMap, err = executeCmd(paramList, client.parameterMap)
+		client.parameterMux.Unlock()
+		if err != nil {
+			if len(gql) > 0 {
+				err = fmt.Errorf("%s. %s.\n", err.Error(), InterruptError.Error())
+			}
+			return ChannelResponse{
+				Result: nil,
+				Params: showMap,
+				Error:  err,
+			}
+		}
+		if err := client.save(); err != nil {
+			// the parameters still work in memory, so only report it
+			defer func() {
+				response.Msg = fmt.Sprintf("save the parameters of %s failed: %s", client.nsid, err)
 			}()
-		case <-client.CloseChannel:
-			clientMux.Lock()
-			_ = client.graphClient.Close()
-			currentClientNum--
-			delete(clientPool, nsid)
-			clientMux.Unlock()
-			return // Exit loop
 		}
 	}
+
+	if len(gql) > 0 {
+		execResponse, err := graphClient.ExecuteWithParameter([]byte(gql), client.parameters())
+		if err != nil {
+			// the graph client has failed over to every endpoint before it gives up
+			if isThriftProtoError(err) || isThriftTransportError(err) || err == nerrors.ErrNoValidGraphEndpoint {
+				err = ConnectionClosedError
+			}
+			return ChannelResponse{
+				Result: nil,
+				Error:  err,
+			}
+		}
+
+		res, err := wrapper.GenResultSet(execResponse, graphClient.Factory(), client.timezone)
+		if err != nil {
+			err = fmt.Errorf("%s. %s.\n", err.Error(), InterruptError.Error())
+		}
+		return ChannelResponse{
+			Result: res,
+			Params: showMap,
+			Error:  err,
+		}
+	}
+	return ChannelResponse{
+		Result: nil,
+		Params: showMap,
+		Error:  nil,
+	}
+}
+
+// executeBatchRequest executes the items one by one, with StopOnError the items after the first failed one are not executed.
+func (client *Client) executeBatchRequest(graphClient nebula.GraphClient, request BatchChannelRequest) []ChannelResponse {
+	responses := make([]ChannelResponse, 0, len(request.Items))
+	for i, item := range request.Items {
+		response := client.executeRequest(graphClient, item.Gql, item.ParamList)
+		responses = append(responses, response)
+		if !request.StopOnError || !isFailedResponse(response) {
+			continue
+		}
+		for range request.Items[i+1:] {
+			responses = append(responses, ChannelResponse{
+				Result: nil,
+				Error:  NotExecutedError,
+			})
+		}
+		break
+	}
+	return responses
+}
+
+// parameters returns a copy of the parameters shared by the graphd sessions.
+func (client *Client) parameters() types.ParameterMap {
+	client.parameterMux.RLock()
+	defer client.parameterMux.RUnlock()
+	params := make(types.ParameterMap, len(client.parameterMap))
+	for k, v := range client.parameterMap {
+		params[k] = v
+	}
+	return params
+}
+
+func isFailedResponse(response ChannelResponse) bool {
+	if response.Error != nil {
+		return true
+	}
+	return response.Result != nil && !response.Result.IsSetPlanDesc() && !response.Result.IsSucceed()
+}
+
+/*
+`KillRunningQueries` kills the queries running in the graphd session which executes the request of responseChannel,
+it uses another session since that one is blocked by the running query.
+A tracked request which is not taken by a graphd session yet is cancelled instead.
+*/
+func (client *Client) KillRunningQueries(responseChannel chan ChannelResponse) error {
+	client.runningMux.Lock()
+	sessionID, ok := client.running[responseChannel]
+	if ok && sessionID == notSentSessionID {
+		client.running[responseChannel] = cancelSessionID
+	}
+	client.runningMux.Unlock()
+	if !ok || sessionID < 0 {
+		return nil
+	}
+
+	opts := append([]nebula.Option{}, client.opts...)
+	opts = append(opts, nebula.WithVersion(client.graphClients[0].Version()))
+	c, err := nebula.NewGraphClient(client.endpoints, client.account.username, client.password(), opts...)
+	if err != nil {
+		return err
+	}
+	if err := c.Open(); err != nil {
+		return err
+	}
+	defer c.Close()
+
+	// SHOW QUERIES lists the running queries of all the sessions, including the one blocked
+	execResponse, err := c.Execute([]byte("SHOW QUERIES"))
+	if err != nil {
+		return err
+	}
+	res, err := wrapper.GenResultSet(execResponse, c.Factory(), c.GetTimezoneInfo())
+	if err != nil {
+		return err
+	}
+	if !res.IsSucceed() {
+		return errors.New(res.GetErrorMsg())
+	}
+	sessionIDs, err := res.GetValuesByColName("SessionID")
+	if err != nil {
+		return err
+	}
+	planIDs, err := res.GetValuesByColName("ExecutionPlanID")
+	if err != nil {
+		return err
+	}
+	stmts, err := killQueryStatements(sessionIDs, planIDs, sessionID)
+	if err != nil {
+		return err
+	}
+
+	for _, stmt := range stmts {
+		execResponse, err := c.Execute([]byte(stmt))
+		if err != nil {
+			return err
+		}
+		if execResponse.GetErrorCode() != nerrors.ErrorCode_SUCCEEDED {
+			return fmt.Errorf("%s: %s", stmt, execResponse.GetErrorMsg())
+		}
+	}
+	return nil
+}
+
+// killQueryStatements builds the KILL QUERY statements of the plans running in the graphd session sessionID
+func killQueryStatements(sessionIDs, planIDs []*wrapper.ValueWrapper, sessionID int64) ([]string, error) {
+	if len(sessionIDs) != len(planIDs) {
+		return nil, fmt.Errorf("got %d session ids and %d plan ids", len(sessionIDs), len(planIDs))
+	}
+	var stmts []string
+	for i := range sessionIDs {
+		if id, err := sessionIDs[i].AsInt(); err != nil || id != sessionID {
+			continue
+		}
+		planID, err := planIDs[i].AsInt()
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, fmt.Sprintf("KILL QUERY (session=%d, plan=%d)", sessionID, planID))
+	}
+	return stmts, nil
 }
 
 func GetClient(nsid string) (*Client, error) {
 	clientMux.Lock()
-	defer clientMux.Unlock()
-
-	if client, ok := clientPool[nsid]; ok {
+	client, ok := clientPool[nsid]
+	if ok {
 		client.updateTime = time.Now().Unix()
-		return client, nil
 	}
+	clientMux.Unlock()
 
-	return nil, ClientNotExistedError
+	if !ok {
+		// the client may be lost by restarting, try to restore it
+		return restoreClient(nsid)
+	}
+	if sessionStore != nil && time.Now().Unix()-atomic.LoadInt64(&client.storeTime) > storeRefreshDuration {
+		_ = client.save()
+	}
+	return client, nil
 }
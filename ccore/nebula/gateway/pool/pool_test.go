@@ -0,0 +1,80 @@
+package pool
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/wrapper"
+)
+
+func TestKillQueryStatements(t *testing.T) {
+	factory, err := nebula.NewFactory(nebula.WithVersion(nebula.Version3_0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ints := func(values ...int64) []*wrapper.ValueWrapper {
+		wrappers := make([]*wrapper.ValueWrapper, 0, len(values))
+		for i := range values {
+			wrappers = append(wrappers, wrapper.NewValueWrapper(factory.NewValueBuilder().IVal(&values[i]).Build(), nil, types.TimezoneInfo{}))
+		}
+		return wrappers
+	}
+	str := wrapper.NewValueWrapper(factory.NewValueBuilder().SVal([]byte("7")).Build(), nil, types.TimezoneInfo{})
+
+	cases := []struct {
+		name       string
+		sessionIDs []*wrapper.ValueWrapper
+		planIDs    []*wrapper.ValueWrapper
+		stmts      []string
+		err        bool
+	}{
+		{
+			name: "no queries",
+		},
+		{
+			name:       "other sessions",
+			sessionIDs: ints(1, 2),
+			planIDs:    ints(10, 20),
+		},
+		{
+			name:       "several plans",
+			sessionIDs: ints(7, 1, 7),
+			planIDs:    ints(70, 10, 71),
+			stmts: []string{
+				"KILL QUERY (session=7, plan=70)",
+				"KILL QUERY (session=7, plan=71)",
+			},
+		},
+		{
+			name:       "session id not an int",
+			sessionIDs: append(ints(7), str),
+			planIDs:    ints(70, 71),
+			stmts:      []string{"KILL QUERY (session=7, plan=70)"},
+		},
+		{
+			name:       "plan id not an int",
+			sessionIDs: ints(7),
+			planIDs:    []*wrapper.ValueWrapper{str},
+			err:        true,
+		},
+		{
+			name:       "columns of different lengths",
+			sessionIDs: ints(7, 7),
+			planIDs:    ints(70),
+			err:        true,
+		},
+	}
+
+	for _, tc := range cases {
+		stmts, err := killQueryStatements(tc.sessionIDs, tc.planIDs, 7)
+		if (err != nil) != tc.err {
+			t.Errorf("%s: got error %v, want error %v", tc.name, err, tc.err)
+			continue
+		}
+		if !reflect.DeepEqual(stmts, tc.stmts) {
+			t.Errorf("%s: got %q, want %q", tc.name, stmts, tc.stmts)
+		}
+	}
+}
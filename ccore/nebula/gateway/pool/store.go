@@ -0,0 +1,215 @@
+package pool
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
+)
+
+const (
+	// StoredSessionExpiredDuration is how long a stored session can be restored after its last use
+	StoredSessionExpiredDuration int64 = 3600 * 24
+	// storeRefreshDuration limits how often the update time of a stored session is written
+	storeRefreshDuration int64 = 600
+)
+
+var (
+	sessionStore SessionStore
+	sessionKey   []byte
+	restoreMux   sync.Mutex
+	// sessionTLSConfig is used to restore the sessions which used tls, since the certificates are not stored
+	sessionTLSConfig *tls.Config
+
+	NoSessionSecretError = errors.New("a secret is required to encrypt the stored sessions")
+)
+
+type (
+	// SessionStore persists the clients, so they can be restored after the gateway restarts.
+	SessionStore interface {
+		Save(session *StoredSession) error
+		// Load returns false if nsid is not stored.
+		Load(nsid string) (*StoredSession, bool, error)
+		Delete(nsid string) error
+		// DeleteExpired deletes the sessions not updated since updatedBefore.
+		DeleteExpired(updatedBefore int64) error
+	}
+
+	StoredSession struct {
+		Nsid              string         `json:"nsid"`
+		Endpoints         []string       `json:"endpoints"`
+		MetaEndpoints     []string       `json:"metaEndpoints,omitempty"`
+		Username          string         `json:"username"`
+		EncryptedPassword []byte         `json:"encryptedPassword"`
+		Version           nebula.Version `json:"version"`
+		// Options is nil for the sessions stored before the options are persisted
+		Options      *nebula.StoredOptions `json:"options,omitempty"`
+		ParameterMap types.ParameterMap    `json:"parameterMap"`
+		UpdateTime   int64                 `json:"updateTime"`
+	}
+)
+
+/*
+`SetSessionStore` makes the clients persisted in store,
+the passwords are encrypted with a key derived from secret
+*/
+func SetSessionStore(store SessionStore, secret string) error {
+	if len(secret) == 0 {
+		return NoSessionSecretError
+	}
+	key := sha256.Sum256([]byte(secret))
+	if err := store.DeleteExpired(time.Now().Unix() - StoredSessionExpiredDuration); err != nil {
+		return err
+	}
+	sessionStore = store
+	sessionKey = key[:]
+	return nil
+}
+
+/*
+`SetSessionTLSConfig` sets the tls config to restore the stored sessions which used tls,
+without it the stored server name and the system roots are used
+*/
+func SetSessionTLSConfig(tlsConfig *tls.Config) {
+	sessionTLSConfig = tlsConfig
+}
+
+func (client *Client) save() error {
+	if sessionStore == nil {
+		return nil
+	}
+	encryptedPassword, err := encryptPassword(client.password())
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+	options := nebula.StoreOptions(client.opts...)
+	err = sessionStore.Save(&StoredSession{
+		Nsid:              client.nsid,
+		Endpoints:         client.endpoints,
+		MetaEndpoints:     client.metaEndpoints,
+		Username:          client.account.username,
+		EncryptedPassword: encryptedPassword,
+		Version:           client.graphClients[0].Version(),
+		Options:           &options,
+		ParameterMap:      client.parameters(),
+		UpdateTime:        now,
+	})
+	if err != nil {
+		return err
+	}
+	atomic.StoreInt64(&client.storeTime, now)
+	return nil
+}
+
+func deleteStoredSession(nsid string) {
+	if sessionStore == nil {
+		return
+	}
+	_ = sessionStore.Delete(nsid)
+}
+
+/*
+`restoreClient` reopens the client of nsid from the session store,
+it's used when the client is not in memory after the gateway restarts
+*/
+func restoreClient(nsid string) (*Client, error) {
+	if sessionStore == nil {
+		return nil, ClientNotExistedError
+	}
+
+	restoreMux.Lock()
+	defer restoreMux.Unlock()
+
+	// it may be restored by another request while waiting
+	clientMux.Lock()
+	client, ok := clientPool[nsid]
+	clientMux.Unlock()
+	if ok {
+		return client, nil
+	}
+
+	session, ok, err := sessionStore.Load(nsid)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ClientNotExistedError
+	}
+	if time.Now().Unix() > session.UpdateTime+StoredSessionExpiredDuration {
+		deleteStoredSession(nsid)
+		return nil, ClientNotExistedError
+	}
+
+	if err := checkClientNum(); err != nil {
+		return nil, err
+	}
+
+	password, err := decryptPassword(session.EncryptedPassword)
+	if err != nil {
+		return nil, err
+	}
+	var opts []nebula.Option
+	if session.Options != nil {
+		opts = append(opts, nebula.WithStoredOptions(*session.Options, sessionTLSConfig))
+	}
+	graphClients, err := openGraphClients(session.Endpoints, session.Username, password, sessionWidth, append(opts, nebula.WithVersion(session.Version))...)
+	if err != nil {
+		return nil, err
+	}
+
+	client = newClient(nsid, graphClients, session.Endpoints, session.Username, password, opts...)
+	client.metaEndpoints = session.MetaEndpoints
+	if session.ParameterMap != nil {
+		client.parameterMap = session.ParameterMap
+	}
+	client.storeTime = session.UpdateTime
+	registerClient(client)
+	return client, nil
+}
+
+func encryptPassword(password string) ([]byte, error) {
+	gcm, err := newGCM()
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, []byte(password), nil), nil
+}
+
+func decryptPassword(encrypted []byte) (string, error) {
+	gcm, err := newGCM()
+	if err != nil {
+		return "", err
+	}
+	if len(encrypted) < gcm.NonceSize() {
+		return "", errors.New("invalid encrypted password")
+	}
+	nonce, ciphertext := encrypted[:gcm.NonceSize()], encrypted[gcm.NonceSize():]
+	password, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(password), nil
+}
+
+func newGCM() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(sessionKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
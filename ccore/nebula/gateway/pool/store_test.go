@@ -0,0 +1,56 @@
+package pool
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+)
+
+func setSessionKey(secret string) {
+	key := sha256.Sum256([]byte(secret))
+	sessionKey = key[:]
+}
+
+func TestEncryptPassword(t *testing.T) {
+	defer func(key []byte) { sessionKey = key }(sessionKey)
+	setSessionKey("secret")
+
+	for _, password := range []string{"nebula", "", "密码 with spaces"} {
+		encrypted, err := encryptPassword(password)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(password) > 0 && bytes.Contains(encrypted, []byte(password)) {
+			t.Errorf("%q: the password is stored in plain text", password)
+		}
+		decrypted, err := decryptPassword(encrypted)
+		if err != nil {
+			t.Errorf("%q: %v", password, err)
+			continue
+		}
+		if decrypted != password {
+			t.Errorf("got %q, want %q", decrypted, password)
+		}
+	}
+
+	// the nonce is random, so the same password is encrypted differently
+	first, _ := encryptPassword("nebula")
+	second, _ := encryptPassword("nebula")
+	if bytes.Equal(first, second) {
+		t.Error("the same password is encrypted to the same bytes")
+	}
+
+	tampered := append([]byte{}, first...)
+	tampered[len(tampered)-1] ^= 1
+	if _, err := decryptPassword(tampered); err == nil {
+		t.Error("a tampered password is decrypted")
+	}
+	if _, err := decryptPassword(first[:4]); err == nil {
+		t.Error("a truncated password is decrypted")
+	}
+
+	setSessionKey("another secret")
+	if _, err := decryptPassword(first); err == nil {
+		t.Error("a password is decrypted with another secret")
+	}
+}
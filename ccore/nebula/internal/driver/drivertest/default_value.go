@@ -0,0 +1,63 @@
+// Package drivertest shares the test cases of the drivers, which are the same for all the versions.
+package drivertest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
+)
+
+type defaultValueCase struct {
+	name  string
+	build func(b types.ValueBuilder) types.ValueBuilder
+}
+
+var defaultValueCases = func() []defaultValueCase {
+	i := int64(42)
+	f := 3.5
+	b := true
+	null := types.NullType(0)
+	return []defaultValueCase{
+		{"int", func(vb types.ValueBuilder) types.ValueBuilder { return vb.IVal(&i) }},
+		{"float", func(vb types.ValueBuilder) types.ValueBuilder { return vb.FVal(&f) }},
+		{"bool", func(vb types.ValueBuilder) types.ValueBuilder { return vb.BVal(&b) }},
+		{"string", func(vb types.ValueBuilder) types.ValueBuilder { return vb.SVal([]byte("nebula")) }},
+		{"empty string", func(vb types.ValueBuilder) types.ValueBuilder { return vb.SVal([]byte{}) }},
+		{"null", func(vb types.ValueBuilder) types.ValueBuilder { return vb.NVal(&null) }},
+	}
+}()
+
+/*
+`TestDefaultValue` checks that the values built by factory are decoded as they are encoded,
+and the expressions which are not constants of kind are decoded as nil
+*/
+func TestDefaultValue(t *testing.T, factory types.FactoryDriver, kind byte,
+	encode func(types.Value) ([]byte, error), decode func([]byte) types.Value) {
+	for _, tc := range defaultValueCases {
+		value := tc.build(factory.NewValueBuilder()).Build()
+		expr, err := encode(value)
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		if len(expr) < 2 || expr[0] != kind {
+			t.Errorf("%s: got %v, want a constant expression", tc.name, expr)
+			continue
+		}
+		decoded := decode(expr)
+		if decoded == nil {
+			t.Errorf("%s: failed to decode %v", tc.name, expr)
+			continue
+		}
+		if !reflect.DeepEqual(decoded.Unwrap(), value.Unwrap()) {
+			t.Errorf("%s: got %v, want %v", tc.name, decoded.Unwrap(), value.Unwrap())
+		}
+	}
+
+	for _, expr := range [][]byte{nil, {kind}, {kind + 1, 0}} {
+		if value := decode(expr); value != nil {
+			t.Errorf("%v: got %v, want nil", expr, value)
+		}
+	}
+}
@@ -2,6 +2,9 @@ package v2_5
 
 import (
 	"github.com/facebook/fbthrift/thrift/lib/go/thrift"
+
+	nerrors "github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/errors"
+	nthrift "github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/internal/thrift/v2_5"
 	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/internal/thrift/v2_5/storage"
 	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
 )
@@ -31,6 +34,167 @@ func (c *defaultStorageAdminClient) Open() error {
 	return c.storageAdmin.Open()
 }
 
+func (c *defaultStorageAdminClient) TransLeader(spaceID int32, partID int32, newLeader string) (types.StorageAdminResult, error) {
+	leader, err := toHostAddr(newLeader)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.storageAdmin.TransLeader(&storage.TransLeaderReq{
+		SpaceID:    nthrift.GraphSpaceID(spaceID),
+		PartID:     nthrift.PartitionID(partID),
+		NewLeader_: leader,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newStorageAdminResultWrapper(resp.GetResult_()), nil
+}
+
+func (c *defaultStorageAdminClient) AddPart(spaceID int32, partID int32, asLearner bool, peers []string) (types.StorageAdminResult, error) {
+	hosts, err := toHostAddrList(peers)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.storageAdmin.AddPart(&storage.AddPartReq{
+		SpaceID:   nthrift.GraphSpaceID(spaceID),
+		PartID:    nthrift.PartitionID(partID),
+		AsLearner: asLearner,
+		Peers:     hosts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newStorageAdminResultWrapper(resp.GetResult_()), nil
+}
+
+func (c *defaultStorageAdminClient) RemovePart(spaceID int32, partID int32) (types.StorageAdminResult, error) {
+	resp, err := c.storageAdmin.RemovePart(&storage.RemovePartReq{
+		SpaceID: nthrift.GraphSpaceID(spaceID),
+		PartID:  nthrift.PartitionID(partID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newStorageAdminResultWrapper(resp.GetResult_()), nil
+}
+
+func (c *defaultStorageAdminClient) MemberChange(spaceID int32, partID int32, peer string, add bool) (types.StorageAdminResult, error) {
+	host, err := toHostAddr(peer)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.storageAdmin.MemberChange(&storage.MemberChangeReq{
+		SpaceID: nthrift.GraphSpaceID(spaceID),
+		PartID:  nthrift.PartitionID(partID),
+		Peer:    host,
+		Add:     add,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newStorageAdminResultWrapper(resp.GetResult_()), nil
+}
+
+// CreateCheckpoint creates the checkpoint of each space in turn, the space of a request is a single one before 3.0
+func (c *defaultStorageAdminClient) CreateCheckpoint(spaceIDs []int32, name string) (types.CheckpointResult, error) {
+	result := checkpointResultWrapper{
+		storageAdminResultWrapper: storageAdminResultWrapper{
+			failedParts: make([]types.PartResult, 0),
+		},
+		checkpoints: make([]types.CheckpointInfo, 0),
+	}
+	for _, spaceID := range spaceIDs {
+		resp, err := c.storageAdmin.CreateCheckpoint(&storage.CreateCPRequest{
+			SpaceID: nthrift.GraphSpaceID(spaceID),
+			Name:    []byte(name),
+		})
+		if err != nil {
+			return nil, err
+		}
+		result.merge(newStorageAdminResultWrapper(resp.GetResult_()))
+		for _, info := range resp.GetInfo() {
+			result.checkpoints = append(result.checkpoints, toCheckpointInfo(spaceID, info))
+		}
+	}
+
+	return result, nil
+}
+
+func (c *defaultStorageAdminClient) DropCheckpoint(spaceIDs []int32, name string) (types.StorageAdminResult, error) {
+	result := storageAdminResultWrapper{
+		failedParts: make([]types.PartResult, 0),
+	}
+	for _, spaceID := range spaceIDs {
+		resp, err := c.storageAdmin.DropCheckpoint(&storage.DropCPRequest{
+			SpaceID: nthrift.GraphSpaceID(spaceID),
+			Name:    []byte(name),
+		})
+		if err != nil {
+			return nil, err
+		}
+		result.merge(newStorageAdminResultWrapper(resp.GetResult_()))
+	}
+
+	return result, nil
+}
+
+func (c *defaultStorageAdminClient) BlockingWrites(spaceIDs []int32, sign types.EngineSignType) (types.StorageAdminResult, error) {
+	engineSign, err := toEngineSignType(sign)
+	if err != nil {
+		return nil, err
+	}
+
+	result := storageAdminResultWrapper{
+		failedParts: make([]types.PartResult, 0),
+	}
+	for _, spaceID := range spaceIDs {
+		resp, err := c.storageAdmin.BlockingWrites(&storage.BlockingSignRequest{
+			SpaceID: nthrift.GraphSpaceID(spaceID),
+			Sign:    engineSign,
+		})
+		if err != nil {
+			return nil, err
+		}
+		result.merge(newStorageAdminResultWrapper(resp.GetResult_()))
+	}
+
+	return result, nil
+}
+
+func (c *defaultStorageAdminClient) GetLeaderParts() (types.LeaderParts, error) {
+	resp, err := c.storageAdmin.GetLeaderParts(&storage.GetLeaderReq{})
+	if err != nil {
+		return nil, err
+	}
+
+	return newLeaderPartsWrapper(resp), nil
+}
+
+func (c *defaultStorageAdminClient) CheckPeers(spaceID int32, partID int32, peers []string) (types.StorageAdminResult, error) {
+	hosts, err := toHostAddrList(peers)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.storageAdmin.CheckPeers(&storage.CheckPeersReq{
+		SpaceID: nthrift.GraphSpaceID(spaceID),
+		PartID:  nthrift.PartitionID(partID),
+		Peers:   hosts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newStorageAdminResultWrapper(resp.GetResult_()), nil
+}
+
 func (c *defaultStorageAdminClient) Close() error {
 	if c.storageAdmin != nil {
 		if err := c.storageAdmin.Close(); err != nil {
@@ -39,3 +203,23 @@ func (c *defaultStorageAdminClient) Close() error {
 	}
 	return nil
 }
+
+func toHostAddrList(endpoints []string) ([]*nthrift.HostAddr, error) {
+	hosts := make([]*nthrift.HostAddr, 0, len(endpoints))
+	for _, ep := range endpoints {
+		host, err := toHostAddr(ep)
+		if err != nil {
+			return nil, err
+		}
+		hosts = append(hosts, host)
+	}
+	return hosts, nil
+}
+
+func toEngineSignType(sign types.EngineSignType) (storage.EngineSignType, error) {
+	engineSign, err := storage.EngineSignTypeFromString(string(sign))
+	if err != nil {
+		return engineSign, nerrors.ErrUnknownEngineSign
+	}
+	return engineSign, nil
+}
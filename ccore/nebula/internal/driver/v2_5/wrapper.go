@@ -2,11 +2,16 @@ package v2_5
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/facebook/fbthrift/thrift/lib/go/thrift"
 
 	nerrors "github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/errors"
 	nthrift "github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/internal/thrift/v2_5"
 	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/internal/thrift/v2_5/graph"
 	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/internal/thrift/v2_5/meta"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/internal/thrift/v2_5/storage"
 	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
 )
 
@@ -372,14 +377,10 @@ func (b valueBuilder) Build() types.Value {
 
 func (b valueBuilder) build() *nthrift.Value {
 	value := nthrift.NewValue()
-	nval := b.value.GetNVal()
-	value.NVal = &nval
-	bval := b.value.GetBVal()
-	value.BVal = &bval
-	ival := b.value.GetIVal()
-	value.IVal = &ival
-	fval := b.value.GetFVal()
-	value.FVal = &fval
+	value.NVal = b.value.NVal
+	value.BVal = b.value.BVal
+	value.IVal = b.value.IVal
+	value.FVal = b.value.FVal
 	value.SVal = b.value.GetSVal()
 	value.DVal = b.value.GetDVal()
 	value.TVal = b.value.GetTVal()
@@ -1237,3 +1238,836 @@ func (m metaBaserWrap) GetCode() nerrors.ErrorCode {
 func (m metaBaserWrap) GetLeader() string {
 	return fmt.Sprintf("%s:%d", m.leader.Host, m.leader.Port)
 }
+
+const (
+	// latestSchemaVersion gets the latest version of a schema
+	latestSchemaVersion meta.SchemaVer = -1
+	// constantExprKind is the kind of a constant expression encoded by nebula
+	constantExprKind byte = 0
+)
+
+type schemaItemsWrapper struct {
+	metaBaserWrap
+	items []types.SchemaItem
+}
+
+func (w schemaItemsWrapper) GetItems() []types.SchemaItem {
+	return w.items
+}
+
+func newTagItemsWrapper(resp *meta.ListTagsResp) types.SchemaItems {
+	items := make([]types.SchemaItem, 0, len(resp.GetTags()))
+	for _, tag := range resp.GetTags() {
+		items = append(items, types.SchemaItem{
+			ID:      int32(tag.GetTagID()),
+			Name:    string(tag.GetTagName()),
+			Version: int64(tag.GetVersion()),
+			Schema:  toSchema(tag.GetSchema()),
+		})
+	}
+	return schemaItemsWrapper{
+		metaBaserWrap: newMetaBaserWrap(resp.GetCode(), resp.GetLeader()),
+		items:         items,
+	}
+}
+
+func newEdgeItemsWrapper(resp *meta.ListEdgesResp) types.SchemaItems {
+	items := make([]types.SchemaItem, 0, len(resp.GetEdges()))
+	for _, edge := range resp.GetEdges() {
+		items = append(items, types.SchemaItem{
+			ID:      int32(edge.GetEdgeType()),
+			Name:    string(edge.GetEdgeName()),
+			Version: int64(edge.GetVersion()),
+			Schema:  toSchema(edge.GetSchema()),
+		})
+	}
+	return schemaItemsWrapper{
+		metaBaserWrap: newMetaBaserWrap(resp.GetCode(), resp.GetLeader()),
+		items:         items,
+	}
+}
+
+type schemaWrapper struct {
+	metaBaserWrap
+	schema types.Schema
+}
+
+func (w schemaWrapper) GetSchema() types.Schema {
+	return w.schema
+}
+
+func newSchemaWrapper(code nthrift.ErrorCode, leader *nthrift.HostAddr, schema *meta.Schema) types.SchemaResult {
+	return schemaWrapper{
+		metaBaserWrap: newMetaBaserWrap(code, leader),
+		schema:        toSchema(schema),
+	}
+}
+
+type indexItemsWrapper struct {
+	metaBaserWrap
+	items []types.IndexItem
+}
+
+func (w indexItemsWrapper) GetItems() []types.IndexItem {
+	return w.items
+}
+
+func newIndexItemsWrapper(code nthrift.ErrorCode, leader *nthrift.HostAddr, indexes []*meta.IndexItem) types.IndexItems {
+	items := make([]types.IndexItem, 0, len(indexes))
+	for _, index := range indexes {
+		item := types.IndexItem{
+			ID:         int32(index.GetIndexID()),
+			Name:       string(index.GetIndexName()),
+			SchemaName: string(index.GetSchemaName()),
+			Fields:     toColumnDefs(index.GetFields()),
+			Comment:    string(index.GetComment()),
+		}
+		if schemaID := index.GetSchemaID(); schemaID != nil {
+			if schemaID.IsSetEdgeType() {
+				item.SchemaID = int32(schemaID.GetEdgeType())
+				item.IsEdge = true
+			} else {
+				item.SchemaID = int32(schemaID.GetTagID())
+			}
+		}
+		items = append(items, item)
+	}
+	return indexItemsWrapper{
+		metaBaserWrap: newMetaBaserWrap(code, leader),
+		items:         items,
+	}
+}
+
+func toSchema(schema *meta.Schema) types.Schema {
+	if schema == nil {
+		return types.Schema{}
+	}
+	prop := schema.GetSchemaProp()
+	return types.Schema{
+		Columns: toColumnDefs(schema.GetColumns()),
+		Prop: types.SchemaProp{
+			TTLDuration: prop.GetTtlDuration(),
+			TTLCol:      string(prop.GetTtlCol()),
+			Comment:     string(prop.GetComment()),
+		},
+	}
+}
+
+func toColumnDefs(columns []*meta.ColumnDef) []types.ColumnDef {
+	defs := make([]types.ColumnDef, 0, len(columns))
+	for _, column := range columns {
+		def := types.ColumnDef{
+			Name:       string(column.GetName()),
+			Type:       types.PropertyType(column.GetType().GetType().String()),
+			TypeLength: column.GetType().GetTypeLength(),
+			Nullable:   column.GetNullable(),
+			HasDefault: column.IsSetDefaultValue(),
+			Comment:    string(column.GetComment()),
+		}
+		if def.HasDefault {
+			def.DefaultValue = decodeDefaultValue(column.GetDefaultValue())
+		}
+		defs = append(defs, def)
+	}
+	return defs
+}
+
+/*
+`decodeDefaultValue` decodes the default value encoded as an expression by nebula,
+the expression is its kind followed by the compact serialized value if it's a constant
+*/
+func decodeDefaultValue(expr []byte) types.Value {
+	if len(expr) < 2 || expr[0] != constantExprKind {
+		return nil
+	}
+	value := nthrift.NewValue()
+	if err := thrift.NewCompactDeserializer().Read(value, expr[1:]); err != nil {
+		return nil
+	}
+	return newValueWrapper(value)
+}
+
+func newMetaBaserWrap(code nthrift.ErrorCode, leader *nthrift.HostAddr) metaBaserWrap {
+	return metaBaserWrap{
+		code: nerrors.ErrorCode(code),
+		leader: types.HostAddr{
+			Host: leader.GetHost(),
+			Port: leader.GetPort(),
+		},
+	}
+}
+
+type usersWrapper struct {
+	metaBaserWrap
+	users []string
+}
+
+func (w usersWrapper) GetUsers() []string {
+	return w.users
+}
+
+func newUsersWrapper(resp *meta.ListUsersResp) types.Users {
+	users := make([]string, 0, len(resp.GetUsers()))
+	for account := range resp.GetUsers() {
+		users = append(users, account)
+	}
+	sort.Strings(users)
+	return usersWrapper{
+		metaBaserWrap: newMetaBaserWrap(resp.GetCode(), resp.GetLeader()),
+		users:         users,
+	}
+}
+
+type rolesWrapper struct {
+	metaBaserWrap
+	roles []types.RoleItem
+}
+
+func (w rolesWrapper) GetRoles() []types.RoleItem {
+	return w.roles
+}
+
+func newRolesWrapper(resp *meta.ListRolesResp) types.Roles {
+	roles := make([]types.RoleItem, 0, len(resp.GetRoles()))
+	for _, role := range resp.GetRoles() {
+		roles = append(roles, types.RoleItem{
+			Account: string(role.GetUserID()),
+			SpaceID: int32(role.GetSpaceID()),
+			Role:    types.RoleType(role.GetRoleType().String()),
+		})
+	}
+	return rolesWrapper{
+		metaBaserWrap: newMetaBaserWrap(resp.GetCode(), resp.GetLeader()),
+		roles:         roles,
+	}
+}
+
+type sessionsWrapper struct {
+	metaBaserWrap
+	sessions []types.SessionDesc
+}
+
+func (w sessionsWrapper) GetSessions() []types.SessionDesc {
+	return w.sessions
+}
+
+func newSessionsWrapper(resp *meta.ListSessionsResp) types.Sessions {
+	sessions := make([]types.SessionDesc, 0, len(resp.GetSessions()))
+	for _, session := range resp.GetSessions() {
+		sessions = append(sessions, toSessionDesc(session))
+	}
+	return sessionsWrapper{
+		metaBaserWrap: newMetaBaserWrap(resp.GetCode(), resp.GetLeader()),
+		sessions:      sessions,
+	}
+}
+
+type sessionWrapper struct {
+	metaBaserWrap
+	session types.SessionDesc
+}
+
+func (w sessionWrapper) GetSession() types.SessionDesc {
+	return w.session
+}
+
+func newSessionWrapper(resp *meta.GetSessionResp) types.SessionResult {
+	w := sessionWrapper{
+		metaBaserWrap: newMetaBaserWrap(resp.GetCode(), resp.GetLeader()),
+	}
+	if resp.IsSetSession() {
+		w.session = toSessionDesc(resp.GetSession())
+	}
+	return w
+}
+
+func toSessionDesc(session *meta.Session) types.SessionDesc {
+	queries := make([]types.QueryDesc, 0, len(session.GetQueries()))
+	for planID, query := range session.GetQueries() {
+		queries = append(queries, types.QueryDesc{
+			PlanID:    int64(planID),
+			Query:     string(query.GetQuery()),
+			Status:    types.QueryStatus(query.GetStatus().String()),
+			StartTime: int64(query.GetStartTime()),
+			Duration:  query.GetDuration(),
+			GraphAddr: types.HostAddr{
+				Host: query.GetGraphAddr().GetHost(),
+				Port: query.GetGraphAddr().GetPort(),
+			},
+		})
+	}
+	sort.Slice(queries, func(i, j int) bool {
+		return queries[i].PlanID < queries[j].PlanID
+	})
+	return types.SessionDesc{
+		ID:    int64(session.GetSessionID()),
+		User:  string(session.GetUserName()),
+		Space: string(session.GetSpaceName()),
+		GraphAddr: types.HostAddr{
+			Host: session.GetGraphAddr().GetHost(),
+			Port: session.GetGraphAddr().GetPort(),
+		},
+		ClientIP:   string(session.GetClientIP()),
+		Timezone:   session.GetTimezone(),
+		CreateTime: int64(session.GetCreateTime()),
+		UpdateTime: int64(session.GetUpdateTime()),
+		Queries:    queries,
+	}
+}
+
+type snapshotsWrapper struct {
+	metaBaserWrap
+	snapshots []types.SnapshotDesc
+}
+
+func (w snapshotsWrapper) GetSnapshots() []types.SnapshotDesc {
+	return w.snapshots
+}
+
+func newSnapshotsWrapper(resp *meta.ListSnapshotsResp) types.Snapshots {
+	snapshots := make([]types.SnapshotDesc, 0, len(resp.GetSnapshots()))
+	for _, snapshot := range resp.GetSnapshots() {
+		hosts := make([]string, 0)
+		if len(snapshot.GetHosts()) > 0 {
+			hosts = strings.Split(string(snapshot.GetHosts()), ",")
+		}
+		snapshots = append(snapshots, types.SnapshotDesc{
+			Name:   string(snapshot.GetName()),
+			Status: types.SnapshotStatus(snapshot.GetStatus().String()),
+			Hosts:  hosts,
+		})
+	}
+	return snapshotsWrapper{
+		metaBaserWrap: newMetaBaserWrap(resp.GetCode(), resp.GetLeader()),
+		snapshots:     snapshots,
+	}
+}
+
+type configsWrapper struct {
+	metaBaserWrap
+	configs []types.ConfigItem
+}
+
+func (w configsWrapper) GetConfigs() []types.ConfigItem {
+	return w.configs
+}
+
+func newConfigsWrapper(code nthrift.ErrorCode, leader *nthrift.HostAddr, items []*meta.ConfigItem) types.Configs {
+	configs := make([]types.ConfigItem, 0, len(items))
+	for _, item := range items {
+		config := types.ConfigItem{
+			Module: types.ConfigModule(item.GetModule().String()),
+			Name:   string(item.GetName()),
+			Mode:   types.ConfigMode(item.GetMode().String()),
+		}
+		if item.IsSetValue() {
+			config.Value = newValueWrapper(item.GetValue())
+		}
+		configs = append(configs, config)
+	}
+	return configsWrapper{
+		metaBaserWrap: newMetaBaserWrap(code, leader),
+		configs:       configs,
+	}
+}
+
+type partsWrapper struct {
+	metaBaserWrap
+	parts []types.PartItem
+}
+
+func (w partsWrapper) GetParts() []types.PartItem {
+	return w.parts
+}
+
+func newPartsWrapper(resp *meta.ListPartsResp) types.Parts {
+	parts := make([]types.PartItem, 0, len(resp.GetParts()))
+	for _, part := range resp.GetParts() {
+		item := types.PartItem{
+			ID:    int32(part.GetPartID()),
+			Peers: toHostAddrs(part.GetPeers()),
+			Losts: toHostAddrs(part.GetLosts()),
+		}
+		if part.IsSetLeader() {
+			item.Leader = &types.HostAddr{
+				Host: part.GetLeader().GetHost(),
+				Port: part.GetLeader().GetPort(),
+			}
+		}
+		parts = append(parts, item)
+	}
+	sort.Slice(parts, func(i, j int) bool {
+		return parts[i].ID < parts[j].ID
+	})
+	return partsWrapper{
+		metaBaserWrap: newMetaBaserWrap(resp.GetCode(), resp.GetLeader()),
+		parts:         parts,
+	}
+}
+
+func toHostAddrs(hosts []*nthrift.HostAddr) []types.HostAddr {
+	addrs := make([]types.HostAddr, 0, len(hosts))
+	for _, host := range hosts {
+		addrs = append(addrs, types.HostAddr{
+			Host: host.GetHost(),
+			Port: host.GetPort(),
+		})
+	}
+	return addrs
+}
+
+type spaceResultWrapper struct {
+	metaBaserWrap
+	item types.SpaceItem
+}
+
+func (w spaceResultWrapper) GetSpace() types.SpaceItem {
+	return w.item
+}
+
+func newSpaceResultWrapper(resp *meta.GetSpaceResp) types.SpaceResult {
+	// there is no item if the space is not found
+	var item types.SpaceItem
+	if resp.IsSetItem() && resp.GetItem().IsSetProperties() {
+		item = toSpaceItem(resp.GetItem())
+	}
+	return spaceResultWrapper{
+		metaBaserWrap: newMetaBaserWrap(resp.GetCode(), resp.GetLeader()),
+		item:          item,
+	}
+}
+
+func toSpaceItem(item *meta.SpaceItem) types.SpaceItem {
+	properties := item.GetProperties()
+	desc := types.SpaceDesc{
+		Name:          string(properties.GetSpaceName()),
+		PartitionNum:  properties.GetPartitionNum(),
+		ReplicaFactor: properties.GetReplicaFactor(),
+		VidType:       types.PropertyType(properties.GetVidType().GetType().String()),
+		VidLength:     properties.GetVidType().GetTypeLength(),
+		Charset:       string(properties.GetCharsetName()),
+		Collate:       string(properties.GetCollateName()),
+		Comment:       string(properties.GetComment()),
+	}
+	return types.SpaceItem{
+		ID:   item.GetSpaceID(),
+		Desc: desc,
+	}
+}
+
+type serviceClientsWrapper struct {
+	metaBaserWrap
+	clients []types.ServiceClient
+}
+
+func (w serviceClientsWrapper) GetClients() []types.ServiceClient {
+	return w.clients
+}
+
+func newServiceClientsWrapper(code nthrift.ErrorCode, leader *nthrift.HostAddr, clients []*meta.FTClient) types.ServiceClients {
+	list := make([]types.ServiceClient, 0, len(clients))
+	for _, client := range clients {
+		list = append(list, types.ServiceClient{
+			Host: types.HostAddr{
+				Host: client.GetHost().GetHost(),
+				Port: client.GetHost().GetPort(),
+			},
+			User:     string(client.GetUser()),
+			Password: string(client.GetPwd()),
+		})
+	}
+	return serviceClientsWrapper{
+		metaBaserWrap: newMetaBaserWrap(code, leader),
+		clients:       list,
+	}
+}
+
+type listenersWrapper struct {
+	metaBaserWrap
+	listeners []types.ListenerInfo
+}
+
+func (w listenersWrapper) GetListeners() []types.ListenerInfo {
+	return w.listeners
+}
+
+func newListenersWrapper(resp *meta.ListListenerResp) types.Listeners {
+	listeners := make([]types.ListenerInfo, 0, len(resp.GetListeners()))
+	for _, listener := range resp.GetListeners() {
+		listeners = append(listeners, types.ListenerInfo{
+			Type: types.ListenerType(listener.GetType().String()),
+			Host: types.HostAddr{
+				Host: listener.GetHost().GetHost(),
+				Port: listener.GetHost().GetPort(),
+			},
+			PartID: listener.GetPartID(),
+			Status: types.HostStatus(listener.GetStatus()),
+		})
+	}
+	return listenersWrapper{
+		metaBaserWrap: newMetaBaserWrap(resp.GetCode(), resp.GetLeader()),
+		listeners:     listeners,
+	}
+}
+
+type ftIndexesWrapper struct {
+	metaBaserWrap
+	indexes []types.FTIndexItem
+}
+
+func (w ftIndexesWrapper) GetIndexes() []types.FTIndexItem {
+	return w.indexes
+}
+
+func newFTIndexesWrapper(resp *meta.ListFTIndexesResp) types.FTIndexes {
+	indexes := make([]types.FTIndexItem, 0, len(resp.GetIndexes()))
+	for name, index := range resp.GetIndexes() {
+		item := types.FTIndexItem{
+			Name:    name,
+			SpaceID: index.GetSpaceID(),
+			Fields:  make([]string, 0, len(index.GetFields())),
+		}
+		if schemaID := index.GetDependSchema(); schemaID != nil {
+			if schemaID.IsSetEdgeType() {
+				item.SchemaID = schemaID.GetEdgeType()
+				item.IsEdge = true
+			} else {
+				item.SchemaID = schemaID.GetTagID()
+			}
+		}
+		for _, field := range index.GetFields() {
+			item.Fields = append(item.Fields, string(field))
+		}
+		indexes = append(indexes, item)
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		return indexes[i].Name < indexes[j].Name
+	})
+	return ftIndexesWrapper{
+		metaBaserWrap: newMetaBaserWrap(resp.GetCode(), resp.GetLeader()),
+		indexes:       indexes,
+	}
+}
+
+type indexStatusesWrapper struct {
+	metaBaserWrap
+	statuses []types.IndexStatusItem
+}
+
+func (w indexStatusesWrapper) GetStatuses() []types.IndexStatusItem {
+	return w.statuses
+}
+
+func newIndexStatusesWrapper(resp *meta.ListIndexStatusResp) types.IndexStatuses {
+	statuses := make([]types.IndexStatusItem, 0, len(resp.GetStatuses()))
+	for _, status := range resp.GetStatuses() {
+		statuses = append(statuses, types.IndexStatusItem{
+			Name:   string(status.GetName()),
+			Status: types.JobStatus(status.GetStatus()),
+		})
+	}
+	return indexStatusesWrapper{
+		metaBaserWrap: newMetaBaserWrap(resp.GetCode(), resp.GetLeader()),
+		statuses:      statuses,
+	}
+}
+
+type indexRebuilderWrapper struct {
+	types.JobSubmitted
+	space  string
+	client *defaultMetaClient
+}
+
+// GetStatus shows the status of the rebuild job, it's FINISHED or FAILED when the job is done
+func (w indexRebuilderWrapper) GetStatus() (types.JobStatus, error) {
+	resp, err := w.client.ShowJob(w.space, w.GetJobID())
+	if err != nil {
+		return "", err
+	}
+	if resp.GetCode() != nerrors.ErrorCode_SUCCEEDED {
+		return "", nerrors.NewCodeError(resp.GetCode(), fmt.Sprintf("failed to show job %d", w.GetJobID()))
+	}
+	if resp.GetJob().Status == "" {
+		return "", nerrors.ErrNoJobStats
+	}
+	return resp.GetJob().Status, nil
+}
+
+type statsResultWrapper struct {
+	metaBaserWrap
+	stats types.StatsItem
+}
+
+func (w statsResultWrapper) GetStats() types.StatsItem {
+	return w.stats
+}
+
+func newStatsResultWrapper(resp *meta.GetStatisResp) types.StatsResult {
+	w := statsResultWrapper{
+		metaBaserWrap: newMetaBaserWrap(resp.GetCode(), resp.GetLeader()),
+	}
+	if item := resp.GetStatis(); item != nil {
+		w.stats = types.StatsItem{
+			TagVertices:               item.GetTagVertices(),
+			Edges:                     item.GetEdges(),
+			SpaceVertices:             item.GetSpaceVertices(),
+			SpaceEdges:                item.GetSpaceEdges(),
+			PositivePartCorrelativity: toCorrelativities(item.GetPositivePartCorrelativity()),
+			NegativePartCorrelativity: toCorrelativities(item.GetNegativePartCorrelativity()),
+			Status:                    types.JobStatus(item.GetStatus().String()),
+		}
+	}
+	return w
+}
+
+func toCorrelativities(parts map[nthrift.PartitionID][]*meta.Correlativity) map[int32][]types.Correlativity {
+	correlativities := make(map[int32][]types.Correlativity, len(parts))
+	for partID, items := range parts {
+		list := make([]types.Correlativity, 0, len(items))
+		for _, item := range items {
+			list = append(list, types.Correlativity{
+				PartID:     int32(item.GetPartID()),
+				Proportion: item.GetProportion(),
+			})
+		}
+		correlativities[int32(partID)] = list
+	}
+	return correlativities
+}
+
+type jobSubmittedWrapper struct {
+	metaBaserWrap
+	jobID int32
+}
+
+func (w jobSubmittedWrapper) GetJobID() int32 {
+	return w.jobID
+}
+
+func newJobSubmittedWrapper(resp *meta.AdminJobResp) types.JobSubmitted {
+	w := jobSubmittedWrapper{
+		metaBaserWrap: newMetaBaserWrap(resp.GetCode(), resp.GetLeader()),
+	}
+	if resp.IsSetResult_() {
+		w.jobID = resp.GetResult_().GetJobID()
+	}
+	return w
+}
+
+type jobsWrapper struct {
+	metaBaserWrap
+	jobs []types.JobDesc
+}
+
+func (w jobsWrapper) GetJobs() []types.JobDesc {
+	return w.jobs
+}
+
+func newJobsWrapper(resp *meta.AdminJobResp) types.Jobs {
+	jobs := make([]types.JobDesc, 0)
+	if resp.IsSetResult_() {
+		for _, job := range resp.GetResult_().GetJobDesc() {
+			jobs = append(jobs, toJobDesc(job))
+		}
+	}
+	return jobsWrapper{
+		metaBaserWrap: newMetaBaserWrap(resp.GetCode(), resp.GetLeader()),
+		jobs:          jobs,
+	}
+}
+
+type jobDetailWrapper struct {
+	metaBaserWrap
+	job   types.JobDesc
+	tasks []types.TaskDesc
+}
+
+func (w jobDetailWrapper) GetJob() types.JobDesc {
+	return w.job
+}
+
+func (w jobDetailWrapper) GetTasks() []types.TaskDesc {
+	return w.tasks
+}
+
+func newJobDetailWrapper(resp *meta.AdminJobResp) types.JobDetail {
+	w := jobDetailWrapper{
+		metaBaserWrap: newMetaBaserWrap(resp.GetCode(), resp.GetLeader()),
+		tasks:         make([]types.TaskDesc, 0),
+	}
+	if !resp.IsSetResult_() {
+		return w
+	}
+	if jobs := resp.GetResult_().GetJobDesc(); len(jobs) > 0 {
+		w.job = toJobDesc(jobs[0])
+	}
+	for _, task := range resp.GetResult_().GetTaskDesc() {
+		w.tasks = append(w.tasks, types.TaskDesc{
+			ID:    task.GetTaskID(),
+			JobID: task.GetJobID(),
+			Host: types.HostAddr{
+				Host: task.GetHost().GetHost(),
+				Port: task.GetHost().GetPort(),
+			},
+			Status:    types.JobStatus(task.GetStatus().String()),
+			StartTime: task.GetStartTime(),
+			StopTime:  task.GetStopTime(),
+		})
+	}
+	return w
+}
+
+type jobsRecoveredWrapper struct {
+	metaBaserWrap
+	recoveredJobNum int32
+}
+
+func (w jobsRecoveredWrapper) GetRecoveredJobNum() int32 {
+	return w.recoveredJobNum
+}
+
+func newJobsRecoveredWrapper(resp *meta.AdminJobResp) types.JobsRecovered {
+	w := jobsRecoveredWrapper{
+		metaBaserWrap: newMetaBaserWrap(resp.GetCode(), resp.GetLeader()),
+	}
+	if resp.IsSetResult_() {
+		w.recoveredJobNum = resp.GetResult_().GetRecoveredJobNum()
+	}
+	return w
+}
+
+func toJobDesc(job *meta.JobDesc) types.JobDesc {
+	return types.JobDesc{
+		ID:        job.GetId(),
+		Cmd:       types.JobCmd(job.GetCmd().String()),
+		Paras:     job.GetParas(),
+		Status:    types.JobStatus(job.GetStatus().String()),
+		StartTime: job.GetStartTime(),
+		StopTime:  job.GetStopTime(),
+	}
+}
+
+type storageAdminResultWrapper struct {
+	failedParts []types.PartResult
+	latencyInUs int64
+}
+
+func (w storageAdminResultWrapper) GetFailedParts() []types.PartResult {
+	return w.failedParts
+}
+
+func (w storageAdminResultWrapper) GetLatencyInUs() int64 {
+	return w.latencyInUs
+}
+
+func newStorageAdminResultWrapper(result *storage.ResponseCommon) storageAdminResultWrapper {
+	w := storageAdminResultWrapper{
+		failedParts: make([]types.PartResult, 0),
+	}
+	if result == nil {
+		return w
+	}
+	for _, part := range result.GetFailedParts() {
+		partResult := types.PartResult{
+			Code:   nerrors.ErrorCode(part.GetCode()),
+			PartID: int32(part.GetPartID()),
+		}
+		if part.IsSetLeader() {
+			partResult.Leader = &types.HostAddr{
+				Host: part.GetLeader().GetHost(),
+				Port: part.GetLeader().GetPort(),
+			}
+		}
+		w.failedParts = append(w.failedParts, partResult)
+	}
+	w.latencyInUs = int64(result.GetLatencyInUs())
+	return w
+}
+
+// merge appends the failed parts of a response of another space, the latencies are summed
+func (w *storageAdminResultWrapper) merge(other storageAdminResultWrapper) {
+	w.failedParts = append(w.failedParts, other.failedParts...)
+	w.latencyInUs += other.latencyInUs
+}
+
+type checkpointResultWrapper struct {
+	storageAdminResultWrapper
+	checkpoints []types.CheckpointInfo
+}
+
+func (w checkpointResultWrapper) GetCheckpoints() []types.CheckpointInfo {
+	return w.checkpoints
+}
+
+// toCheckpointInfo converts the checkpoint of spaceID, which is not in the checkpoint before 3.0
+func toCheckpointInfo(spaceID int32, info *nthrift.CheckpointInfo) types.CheckpointInfo {
+	checkpoint := types.CheckpointInfo{
+		SpaceID: spaceID,
+		Path:    string(info.GetPath()),
+		Parts:   make(map[int32]types.LogInfo),
+	}
+	if info.IsSetPartitionInfo() {
+		checkpoint.Parts = toLogInfos(info.GetPartitionInfo().GetInfo())
+	}
+	return checkpoint
+}
+
+func toLogInfos(parts map[nthrift.PartitionID]*nthrift.LogInfo) map[int32]types.LogInfo {
+	logInfos := make(map[int32]types.LogInfo, len(parts))
+	for partID, logInfo := range parts {
+		logInfos[int32(partID)] = types.LogInfo{
+			LogID:  logInfo.GetLogID(),
+			TermID: logInfo.GetTermID(),
+		}
+	}
+	return logInfos
+}
+
+type leaderPartsWrapper struct {
+	storageAdminResultWrapper
+	leaderParts map[int32][]int32
+}
+
+func (w leaderPartsWrapper) GetLeaderParts() map[int32][]int32 {
+	return w.leaderParts
+}
+
+func newLeaderPartsWrapper(resp *storage.GetLeaderPartsResp) types.LeaderParts {
+	leaderParts := make(map[int32][]int32, len(resp.GetLeaderParts()))
+	for spaceID, partIDs := range resp.GetLeaderParts() {
+		parts := make([]int32, 0, len(partIDs))
+		for _, partID := range partIDs {
+			parts = append(parts, int32(partID))
+		}
+		leaderParts[int32(spaceID)] = parts
+	}
+	return leaderPartsWrapper{
+		storageAdminResultWrapper: newStorageAdminResultWrapper(resp.GetResult_()),
+		leaderParts:               leaderParts,
+	}
+}
+
+type scanResultWrapper struct {
+	storageAdminResultWrapper
+	data       types.DataSet
+	nextCursor []byte
+}
+
+func (w scanResultWrapper) GetData() types.DataSet {
+	return w.data
+}
+
+func (w scanResultWrapper) GetNextCursor() []byte {
+	return w.nextCursor
+}
+
+func newScanResultWrapper(result *storage.ResponseCommon, data *nthrift.DataSet, hasNext bool, nextCursor []byte) types.ScanResult {
+	w := scanResultWrapper{
+		storageAdminResultWrapper: newStorageAdminResultWrapper(result),
+		data:                      newDataSetWrapper(data),
+	}
+	if hasNext {
+		w.nextCursor = nextCursor
+	}
+	return w
+}
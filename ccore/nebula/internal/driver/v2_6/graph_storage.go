@@ -0,0 +1,100 @@
+package v2_6
+
+import (
+	"github.com/facebook/fbthrift/thrift/lib/go/thrift"
+
+	nthrift "github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/internal/thrift/v2_6"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/internal/thrift/v2_6/storage"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
+)
+
+var (
+	_ types.GraphStorageClientDriver = (*defaultGraphStorageClient)(nil)
+)
+
+type (
+	defaultGraphStorageClient struct {
+		graphStorage *storage.GraphStorageServiceClient
+	}
+)
+
+func newGraphStorageClient(transport thrift.Transport, pf thrift.ProtocolFactory) types.GraphStorageClientDriver {
+	return &defaultGraphStorageClient{
+		graphStorage: storage.NewGraphStorageServiceClientFactory(transport, pf),
+	}
+}
+
+func (c *defaultGraphStorageClient) Open() error {
+	return c.graphStorage.Open()
+}
+
+func (c *defaultGraphStorageClient) ScanVertex(req types.ScanReq) (types.ScanResult, error) {
+	scanReq := &storage.ScanVertexRequest{
+		SpaceID: nthrift.GraphSpaceID(req.SpaceID),
+		PartID:  nthrift.PartitionID(req.PartID),
+		Cursor:  req.Cursor,
+		ReturnColumns: &storage.VertexProp{
+			Tag:   nthrift.TagID(req.SchemaID),
+			Props: toPropNames(req.Props),
+		},
+		Limit:                  req.Limit,
+		OnlyLatestVersion:      req.OnlyLatestVersion,
+		EnableReadFromFollower: req.EnableReadFromFollower,
+	}
+	if req.StartTime > 0 {
+		scanReq.StartTime = &req.StartTime
+	}
+	if req.EndTime > 0 {
+		scanReq.EndTime = &req.EndTime
+	}
+	resp, err := c.graphStorage.ScanVertex(scanReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return newScanResultWrapper(resp.GetResult_(), resp.GetVertexData(), resp.GetHasNext(), resp.GetNextCursor()), nil
+}
+
+func (c *defaultGraphStorageClient) ScanEdge(req types.ScanReq) (types.ScanResult, error) {
+	scanReq := &storage.ScanEdgeRequest{
+		SpaceID: nthrift.GraphSpaceID(req.SpaceID),
+		PartID:  nthrift.PartitionID(req.PartID),
+		Cursor:  req.Cursor,
+		ReturnColumns: &storage.EdgeProp{
+			Type:  nthrift.EdgeType(req.SchemaID),
+			Props: toPropNames(req.Props),
+		},
+		Limit:                  req.Limit,
+		OnlyLatestVersion:      req.OnlyLatestVersion,
+		EnableReadFromFollower: req.EnableReadFromFollower,
+	}
+	if req.StartTime > 0 {
+		scanReq.StartTime = &req.StartTime
+	}
+	if req.EndTime > 0 {
+		scanReq.EndTime = &req.EndTime
+	}
+	resp, err := c.graphStorage.ScanEdge(scanReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return newScanResultWrapper(resp.GetResult_(), resp.GetEdgeData(), resp.GetHasNext(), resp.GetNextCursor()), nil
+}
+
+func (c *defaultGraphStorageClient) Close() error {
+	if c.graphStorage != nil {
+		if err := c.graphStorage.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toPropNames(props []string) [][]byte {
+	names := make([][]byte, 0, len(props))
+	for _, prop := range props {
+		names = append(names, []byte(prop))
+	}
+	return names
+}
@@ -1,9 +1,15 @@
 package v2_6
 
 import (
+	"crypto/md5"
+	"encoding/hex"
+	"net"
+	"strconv"
+
 	"github.com/facebook/fbthrift/thrift/lib/go/thrift"
 
 	nerrors "github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/errors"
+	nthrift "github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/internal/thrift/v2_6"
 	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/internal/thrift/v2_6/meta"
 	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
 )
@@ -92,3 +98,1217 @@ func (c *defaultMetaClient) ListHosts() (types.Hosts, error) {
 func (c *defaultMetaClient) ListZones() (types.Zones, error) {
 	return nil, nerrors.ErrUnsupported
 }
+
+func (c *defaultMetaClient) ListTags(space string) (types.SchemaItems, error) {
+	spaceID, base, err := c.getSpaceID(space)
+	if err != nil {
+		return nil, err
+	}
+	if base.code != nerrors.ErrorCode_SUCCEEDED {
+		return schemaItemsWrapper{metaBaserWrap: base}, nil
+	}
+
+	resp, err := c.meta.ListTags(&meta.ListTagsReq{SpaceID: spaceID})
+	if err != nil {
+		return nil, err
+	}
+
+	return newTagItemsWrapper(resp), nil
+}
+
+func (c *defaultMetaClient) GetTag(space string, name string) (types.SchemaResult, error) {
+	spaceID, base, err := c.getSpaceID(space)
+	if err != nil {
+		return nil, err
+	}
+	if base.code != nerrors.ErrorCode_SUCCEEDED {
+		return schemaWrapper{metaBaserWrap: base}, nil
+	}
+
+	req := &meta.GetTagReq{
+		SpaceID: spaceID,
+		TagName: []byte(name),
+		Version: latestSchemaVersion,
+	}
+	resp, err := c.meta.GetTag(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSchemaWrapper(resp.GetCode(), resp.GetLeader(), resp.GetSchema()), nil
+}
+
+func (c *defaultMetaClient) ListEdges(space string) (types.SchemaItems, error) {
+	spaceID, base, err := c.getSpaceID(space)
+	if err != nil {
+		return nil, err
+	}
+	if base.code != nerrors.ErrorCode_SUCCEEDED {
+		return schemaItemsWrapper{metaBaserWrap: base}, nil
+	}
+
+	resp, err := c.meta.ListEdges(&meta.ListEdgesReq{SpaceID: spaceID})
+	if err != nil {
+		return nil, err
+	}
+
+	return newEdgeItemsWrapper(resp), nil
+}
+
+func (c *defaultMetaClient) GetEdge(space string, name string) (types.SchemaResult, error) {
+	spaceID, base, err := c.getSpaceID(space)
+	if err != nil {
+		return nil, err
+	}
+	if base.code != nerrors.ErrorCode_SUCCEEDED {
+		return schemaWrapper{metaBaserWrap: base}, nil
+	}
+
+	req := &meta.GetEdgeReq{
+		SpaceID:  spaceID,
+		EdgeName: []byte(name),
+		Version:  latestSchemaVersion,
+	}
+	resp, err := c.meta.GetEdge(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSchemaWrapper(resp.GetCode(), resp.GetLeader(), resp.GetSchema()), nil
+}
+
+func (c *defaultMetaClient) ListTagIndexes(space string) (types.IndexItems, error) {
+	spaceID, base, err := c.getSpaceID(space)
+	if err != nil {
+		return nil, err
+	}
+	if base.code != nerrors.ErrorCode_SUCCEEDED {
+		return indexItemsWrapper{metaBaserWrap: base}, nil
+	}
+
+	resp, err := c.meta.ListTagIndexes(&meta.ListTagIndexesReq{SpaceID: spaceID})
+	if err != nil {
+		return nil, err
+	}
+
+	return newIndexItemsWrapper(resp.GetCode(), resp.GetLeader(), resp.GetItems()), nil
+}
+
+func (c *defaultMetaClient) ListEdgeIndexes(space string) (types.IndexItems, error) {
+	spaceID, base, err := c.getSpaceID(space)
+	if err != nil {
+		return nil, err
+	}
+	if base.code != nerrors.ErrorCode_SUCCEEDED {
+		return indexItemsWrapper{metaBaserWrap: base}, nil
+	}
+
+	resp, err := c.meta.ListEdgeIndexes(&meta.ListEdgeIndexesReq{SpaceID: spaceID})
+	if err != nil {
+		return nil, err
+	}
+
+	return newIndexItemsWrapper(resp.GetCode(), resp.GetLeader(), resp.GetItems()), nil
+}
+
+// getSpaceID returns the id of space, and the code of the failed lookup if the space is not found
+func (c *defaultMetaClient) getSpaceID(space string) (nthrift.GraphSpaceID, metaBaserWrap, error) {
+	resp, err := c.meta.GetSpace(&meta.GetSpaceReq{SpaceName: []byte(space)})
+	if err != nil {
+		return 0, metaBaserWrap{}, err
+	}
+
+	return resp.GetItem().GetSpaceID(), newMetaBaserWrap(resp.GetCode(), resp.GetLeader()), nil
+}
+
+func (c *defaultMetaClient) CreateUser(account string, password string, ifNotExists bool) (types.MetaBaser, error) {
+	req := &meta.CreateUserReq{
+		Account:     []byte(account),
+		EncodedPwd:  encodePassword(password),
+		IfNotExists: ifNotExists,
+	}
+	resp, err := c.meta.CreateUser(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMetaBaserWrap(resp.GetCode(), resp.GetLeader()), nil
+}
+
+func (c *defaultMetaClient) DropUser(account string, ifExists bool) (types.MetaBaser, error) {
+	req := &meta.DropUserReq{
+		Account:  []byte(account),
+		IfExists: ifExists,
+	}
+	resp, err := c.meta.DropUser(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMetaBaserWrap(resp.GetCode(), resp.GetLeader()), nil
+}
+
+func (c *defaultMetaClient) AlterUser(account string, password string) (types.MetaBaser, error) {
+	req := &meta.AlterUserReq{
+		Account:    []byte(account),
+		EncodedPwd: encodePassword(password),
+	}
+	resp, err := c.meta.AlterUser(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMetaBaserWrap(resp.GetCode(), resp.GetLeader()), nil
+}
+
+func (c *defaultMetaClient) ChangePassword(account string, newPassword string, oldPassword string) (types.MetaBaser, error) {
+	req := &meta.ChangePasswordReq{
+		Account:        []byte(account),
+		NewEncodedPwd_: encodePassword(newPassword),
+		OldEncodedPwd:  encodePassword(oldPassword),
+	}
+	resp, err := c.meta.ChangePassword(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMetaBaserWrap(resp.GetCode(), resp.GetLeader()), nil
+}
+
+func (c *defaultMetaClient) GrantRole(account string, space string, role types.RoleType) (types.MetaBaser, error) {
+	roleItem, base, err := c.roleItem(account, space, role)
+	if err != nil || roleItem == nil {
+		return base, err
+	}
+
+	resp, err := c.meta.GrantRole(&meta.GrantRoleReq{RoleItem: roleItem})
+	if err != nil {
+		return nil, err
+	}
+
+	return newMetaBaserWrap(resp.GetCode(), resp.GetLeader()), nil
+}
+
+func (c *defaultMetaClient) RevokeRole(account string, space string, role types.RoleType) (types.MetaBaser, error) {
+	roleItem, base, err := c.roleItem(account, space, role)
+	if err != nil || roleItem == nil {
+		return base, err
+	}
+
+	resp, err := c.meta.RevokeRole(&meta.RevokeRoleReq{RoleItem: roleItem})
+	if err != nil {
+		return nil, err
+	}
+
+	return newMetaBaserWrap(resp.GetCode(), resp.GetLeader()), nil
+}
+
+func (c *defaultMetaClient) ListUsers() (types.Users, error) {
+	resp, err := c.meta.ListUsers(meta.NewListUsersReq())
+	if err != nil {
+		return nil, err
+	}
+
+	return newUsersWrapper(resp), nil
+}
+
+func (c *defaultMetaClient) ListRoles(space string) (types.Roles, error) {
+	spaceID, base, err := c.getSpaceID(space)
+	if err != nil {
+		return nil, err
+	}
+	if base.code != nerrors.ErrorCode_SUCCEEDED {
+		return rolesWrapper{metaBaserWrap: base}, nil
+	}
+
+	resp, err := c.meta.ListRoles(&meta.ListRolesReq{SpaceID: spaceID})
+	if err != nil {
+		return nil, err
+	}
+
+	return newRolesWrapper(resp), nil
+}
+
+func (c *defaultMetaClient) GetUserRoles(account string) (types.Roles, error) {
+	resp, err := c.meta.GetUserRoles(&meta.GetUserRolesReq{Account: []byte(account)})
+	if err != nil {
+		return nil, err
+	}
+
+	return newRolesWrapper(resp), nil
+}
+
+// roleItem returns nil with the failed response if the space is not got
+func (c *defaultMetaClient) roleItem(account string, space string, role types.RoleType) (*meta.RoleItem, types.MetaBaser, error) {
+	roleType, err := meta.RoleTypeFromString(string(role))
+	if err != nil {
+		return nil, nil, nerrors.ErrUnknownRoleType
+	}
+	spaceID, base, err := c.getSpaceID(space)
+	if err != nil {
+		return nil, nil, err
+	}
+	if base.code != nerrors.ErrorCode_SUCCEEDED {
+		return nil, base, nil
+	}
+
+	return &meta.RoleItem{
+		UserID:   []byte(account),
+		SpaceID:  spaceID,
+		RoleType: roleType,
+	}, base, nil
+}
+
+// encodePassword encodes the password as graphd does, which is the md5 in hex
+func encodePassword(password string) []byte {
+	sum := md5.Sum([]byte(password))
+	return []byte(hex.EncodeToString(sum[:]))
+}
+
+func (c *defaultMetaClient) ListSessions() (types.Sessions, error) {
+	resp, err := c.meta.ListSessions(meta.NewListSessionsReq())
+	if err != nil {
+		return nil, err
+	}
+
+	return newSessionsWrapper(resp), nil
+}
+
+func (c *defaultMetaClient) GetSession(id int64) (types.SessionResult, error) {
+	resp, err := c.meta.GetSession(&meta.GetSessionReq{SessionID: nthrift.SessionID(id)})
+	if err != nil {
+		return nil, err
+	}
+
+	return newSessionWrapper(resp), nil
+}
+
+func (c *defaultMetaClient) RemoveSession(id int64) (types.MetaBaser, error) {
+	resp, err := c.meta.RemoveSession(&meta.RemoveSessionReq{SessionID: nthrift.SessionID(id)})
+	if err != nil {
+		return nil, err
+	}
+
+	return newMetaBaserWrap(resp.GetCode(), resp.GetLeader()), nil
+}
+
+func (c *defaultMetaClient) KillQuery(queries map[int64][]int64) (types.MetaBaser, error) {
+	killQueries := make(map[nthrift.SessionID][]nthrift.ExecutionPlanID, len(queries))
+	for sessionID, planIDs := range queries {
+		ids := make([]nthrift.ExecutionPlanID, 0, len(planIDs))
+		for _, planID := range planIDs {
+			ids = append(ids, nthrift.ExecutionPlanID(planID))
+		}
+		killQueries[nthrift.SessionID(sessionID)] = ids
+	}
+	resp, err := c.meta.KillQuery(&meta.KillQueryReq{KillQueries: killQueries})
+	if err != nil {
+		return nil, err
+	}
+
+	return newMetaBaserWrap(resp.GetCode(), resp.GetLeader()), nil
+}
+
+func (c *defaultMetaClient) CreateSnapshot() (types.MetaBaser, error) {
+	resp, err := c.meta.CreateSnapshot(meta.NewCreateSnapshotReq())
+	if err != nil {
+		return nil, err
+	}
+
+	return newMetaBaserWrap(resp.GetCode(), resp.GetLeader()), nil
+}
+
+func (c *defaultMetaClient) DropSnapshot(name string) (types.MetaBaser, error) {
+	resp, err := c.meta.DropSnapshot(&meta.DropSnapshotReq{Name: []byte(name)})
+	if err != nil {
+		return nil, err
+	}
+
+	return newMetaBaserWrap(resp.GetCode(), resp.GetLeader()), nil
+}
+
+func (c *defaultMetaClient) ListSnapshots() (types.Snapshots, error) {
+	resp, err := c.meta.ListSnapshots(meta.NewListSnapshotsReq())
+	if err != nil {
+		return nil, err
+	}
+
+	return newSnapshotsWrapper(resp), nil
+}
+
+func (c *defaultMetaClient) CreateBackup(spaces []string) (types.BackupResult, error) {
+	return nil, nerrors.ErrUnsupported
+}
+
+func (c *defaultMetaClient) RestoreMeta(files []string, hosts map[string]string) (types.MetaBaser, error) {
+	return nil, nerrors.ErrUnsupported
+}
+
+func (c *defaultMetaClient) ListConfigs(module types.ConfigModule) (types.Configs, error) {
+	configModule, err := toConfigModule(module)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.meta.ListConfigs(&meta.ListConfigsReq{Module: configModule})
+	if err != nil {
+		return nil, err
+	}
+
+	return newConfigsWrapper(resp.GetCode(), resp.GetLeader(), resp.GetItems()), nil
+}
+
+func (c *defaultMetaClient) GetConfig(module types.ConfigModule, name string) (types.Configs, error) {
+	configModule, err := toConfigModule(module)
+	if err != nil {
+		return nil, err
+	}
+	req := &meta.GetConfigReq{
+		Item: &meta.ConfigItem{
+			Module: configModule,
+			Name:   []byte(name),
+		},
+	}
+	resp, err := c.meta.GetConfig(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newConfigsWrapper(resp.GetCode(), resp.GetLeader(), resp.GetItems()), nil
+}
+
+func (c *defaultMetaClient) SetConfig(module types.ConfigModule, name string, value types.Value) (types.MetaBaser, error) {
+	configModule, err := toConfigModule(module)
+	if err != nil {
+		return nil, err
+	}
+	req := &meta.SetConfigReq{
+		Item: &meta.ConfigItem{
+			Module: configModule,
+			Name:   []byte(name),
+			Mode:   meta.ConfigMode_MUTABLE,
+			Value:  value.Unwrap().(*nthrift.Value),
+		},
+	}
+	resp, err := c.meta.SetConfig(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMetaBaserWrap(resp.GetCode(), resp.GetLeader()), nil
+}
+
+// toConfigModule returns ALL if module is empty
+func toConfigModule(module types.ConfigModule) (meta.ConfigModule, error) {
+	if module == "" {
+		return meta.ConfigModule_ALL, nil
+	}
+	configModule, err := meta.ConfigModuleFromString(string(module))
+	if err != nil {
+		return configModule, nerrors.ErrUnknownConfigModule
+	}
+	return configModule, nil
+}
+
+func (c *defaultMetaClient) ListParts(space string, partIDs []int32) (types.Parts, error) {
+	spaceID, base, err := c.getSpaceID(space)
+	if err != nil {
+		return nil, err
+	}
+	if base.code != nerrors.ErrorCode_SUCCEEDED {
+		return partsWrapper{metaBaserWrap: base}, nil
+	}
+
+	req := &meta.ListPartsReq{
+		SpaceID: spaceID,
+		PartIds: make([]nthrift.PartitionID, 0, len(partIDs)),
+	}
+	for _, partID := range partIDs {
+		req.PartIds = append(req.PartIds, nthrift.PartitionID(partID))
+	}
+	resp, err := c.meta.ListParts(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPartsWrapper(resp), nil
+}
+
+func (c *defaultMetaClient) GetZone(zone string) (types.ZoneResult, error) {
+	return nil, nerrors.ErrUnsupported
+}
+
+func (c *defaultMetaClient) DropZone(zone string) (types.MetaBaser, error) {
+	return nil, nerrors.ErrUnsupported
+}
+
+func (c *defaultMetaClient) MergeZone(zones []string, zone string) (types.MetaBaser, error) {
+	return nil, nerrors.ErrUnsupported
+}
+
+func (c *defaultMetaClient) DivideZone(zone string, zoneItems map[string][]string) (types.MetaBaser, error) {
+	return nil, nerrors.ErrUnsupported
+}
+
+func (c *defaultMetaClient) RenameZone(zone string, newZone string) (types.MetaBaser, error) {
+	return nil, nerrors.ErrUnsupported
+}
+
+func (c *defaultMetaClient) CreateSpace(desc types.SpaceDesc, ifNotExists bool) (types.MetaBaser, error) {
+	properties, err := newSpaceDesc(desc)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.meta.CreateSpace(&meta.CreateSpaceReq{
+		Properties:  properties,
+		IfNotExists: ifNotExists,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newMetaBaserWrap(resp.GetCode(), resp.GetLeader()), nil
+}
+
+func (c *defaultMetaClient) CreateSpaceAs(space string, newSpace string) (types.MetaBaser, error) {
+	req := &meta.CreateSpaceAsReq{
+		OldSpaceName:  []byte(space),
+		NewSpaceName_: []byte(newSpace),
+	}
+	resp, err := c.meta.CreateSpaceAs(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMetaBaserWrap(resp.GetCode(), resp.GetLeader()), nil
+}
+
+func (c *defaultMetaClient) DropSpace(space string, ifExists bool) (types.MetaBaser, error) {
+	req := &meta.DropSpaceReq{
+		SpaceName: []byte(space),
+		IfExists:  ifExists,
+	}
+	resp, err := c.meta.DropSpace(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMetaBaserWrap(resp.GetCode(), resp.GetLeader()), nil
+}
+
+func (c *defaultMetaClient) GetSpace(space string) (types.SpaceResult, error) {
+	resp, err := c.meta.GetSpace(&meta.GetSpaceReq{SpaceName: []byte(space)})
+	if err != nil {
+		return nil, err
+	}
+
+	return newSpaceResultWrapper(resp), nil
+}
+
+func (c *defaultMetaClient) AlterSpace(space string, op types.AlterSpaceOp, paras []string) (types.MetaBaser, error) {
+	return nil, nerrors.ErrUnsupported
+}
+
+// newSpaceDesc converts desc into the thrift one, the empty charset, collate and vid type are the defaults of nebula
+func newSpaceDesc(desc types.SpaceDesc) (*meta.SpaceDesc, error) {
+	// the zones of a space are supported since 3.0
+	if len(desc.Zones) > 0 {
+		return nil, nerrors.ErrUnsupported
+	}
+	vidType, err := newVidType(desc)
+	if err != nil {
+		return nil, err
+	}
+	properties := &meta.SpaceDesc{
+		SpaceName:     []byte(desc.Name),
+		PartitionNum:  desc.PartitionNum,
+		ReplicaFactor: desc.ReplicaFactor,
+		CharsetName:   []byte(desc.Charset),
+		CollateName:   []byte(desc.Collate),
+		VidType:       vidType,
+	}
+	if desc.Charset == "" {
+		properties.CharsetName = []byte(types.DefaultSpaceCharset)
+	}
+	if desc.Collate == "" {
+		properties.CollateName = []byte(types.DefaultSpaceCollate)
+	}
+	if desc.Comment != "" {
+		properties.Comment = []byte(desc.Comment)
+	}
+	return properties, nil
+}
+
+func newVidType(desc types.SpaceDesc) (*meta.ColumnTypeDef, error) {
+	if desc.VidType == "" {
+		desc.VidType = types.PropertyType(meta.PropertyType_FIXED_STRING.String())
+	}
+	vidType, err := meta.PropertyTypeFromString(string(desc.VidType))
+	if err != nil {
+		return nil, nerrors.ErrUnknownPropertyType
+	}
+	def := &meta.ColumnTypeDef{Type: vidType}
+	if vidType == meta.PropertyType_FIXED_STRING {
+		def.TypeLength = desc.VidLength
+		if def.TypeLength == 0 {
+			def.TypeLength = types.DefaultSpaceVidLength
+		}
+	}
+	return def, nil
+}
+
+func (c *defaultMetaClient) CreateTag(space string, name string, schema types.Schema, ifNotExists bool) (types.MetaBaser, error) {
+	nSchema, err := newSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	return c.execInSpace(space, func(spaceID nthrift.GraphSpaceID) (*meta.ExecResp, error) {
+		return c.meta.CreateTag(&meta.CreateTagReq{
+			SpaceID:     spaceID,
+			TagName:     []byte(name),
+			Schema:      nSchema,
+			IfNotExists: ifNotExists,
+		})
+	})
+}
+
+func (c *defaultMetaClient) AlterTag(space string, name string, items []types.AlterSchemaItem, prop *types.SchemaProp) (types.MetaBaser, error) {
+	nItems, err := newAlterSchemaItems(items)
+	if err != nil {
+		return nil, err
+	}
+	return c.execInSpace(space, func(spaceID nthrift.GraphSpaceID) (*meta.ExecResp, error) {
+		return c.meta.AlterTag(&meta.AlterTagReq{
+			SpaceID:    spaceID,
+			TagName:    []byte(name),
+			TagItems:   nItems,
+			SchemaProp: newAlterSchemaProp(prop),
+		})
+	})
+}
+
+func (c *defaultMetaClient) DropTag(space string, name string, ifExists bool) (types.MetaBaser, error) {
+	return c.execInSpace(space, func(spaceID nthrift.GraphSpaceID) (*meta.ExecResp, error) {
+		return c.meta.DropTag(&meta.DropTagReq{
+			SpaceID:  spaceID,
+			TagName:  []byte(name),
+			IfExists: ifExists,
+		})
+	})
+}
+
+func (c *defaultMetaClient) CreateEdge(space string, name string, schema types.Schema, ifNotExists bool) (types.MetaBaser, error) {
+	nSchema, err := newSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	return c.execInSpace(space, func(spaceID nthrift.GraphSpaceID) (*meta.ExecResp, error) {
+		return c.meta.CreateEdge(&meta.CreateEdgeReq{
+			SpaceID:     spaceID,
+			EdgeName:    []byte(name),
+			Schema:      nSchema,
+			IfNotExists: ifNotExists,
+		})
+	})
+}
+
+func (c *defaultMetaClient) AlterEdge(space string, name string, items []types.AlterSchemaItem, prop *types.SchemaProp) (types.MetaBaser, error) {
+	nItems, err := newAlterSchemaItems(items)
+	if err != nil {
+		return nil, err
+	}
+	return c.execInSpace(space, func(spaceID nthrift.GraphSpaceID) (*meta.ExecResp, error) {
+		return c.meta.AlterEdge(&meta.AlterEdgeReq{
+			SpaceID:    spaceID,
+			EdgeName:   []byte(name),
+			EdgeItems:  nItems,
+			SchemaProp: newAlterSchemaProp(prop),
+		})
+	})
+}
+
+func (c *defaultMetaClient) DropEdge(space string, name string, ifExists bool) (types.MetaBaser, error) {
+	return c.execInSpace(space, func(spaceID nthrift.GraphSpaceID) (*meta.ExecResp, error) {
+		return c.meta.DropEdge(&meta.DropEdgeReq{
+			SpaceID:  spaceID,
+			EdgeName: []byte(name),
+			IfExists: ifExists,
+		})
+	})
+}
+
+func (c *defaultMetaClient) CreateTagIndex(space string, desc types.IndexDesc, ifNotExists bool) (types.MetaBaser, error) {
+	return c.execInSpace(space, func(spaceID nthrift.GraphSpaceID) (*meta.ExecResp, error) {
+		req := &meta.CreateTagIndexReq{
+			SpaceID:     spaceID,
+			IndexName:   []byte(desc.Name),
+			TagName:     []byte(desc.SchemaName),
+			Fields:      newIndexFields(desc.Fields),
+			IfNotExists: ifNotExists,
+		}
+		if desc.Comment != "" {
+			req.Comment = []byte(desc.Comment)
+		}
+		return c.meta.CreateTagIndex(req)
+	})
+}
+
+func (c *defaultMetaClient) DropTagIndex(space string, name string, ifExists bool) (types.MetaBaser, error) {
+	return c.execInSpace(space, func(spaceID nthrift.GraphSpaceID) (*meta.ExecResp, error) {
+		return c.meta.DropTagIndex(&meta.DropTagIndexReq{
+			SpaceID:   spaceID,
+			IndexName: []byte(name),
+			IfExists:  ifExists,
+		})
+	})
+}
+
+func (c *defaultMetaClient) CreateEdgeIndex(space string, desc types.IndexDesc, ifNotExists bool) (types.MetaBaser, error) {
+	return c.execInSpace(space, func(spaceID nthrift.GraphSpaceID) (*meta.ExecResp, error) {
+		req := &meta.CreateEdgeIndexReq{
+			SpaceID:     spaceID,
+			IndexName:   []byte(desc.Name),
+			EdgeName:    []byte(desc.SchemaName),
+			Fields:      newIndexFields(desc.Fields),
+			IfNotExists: ifNotExists,
+		}
+		if desc.Comment != "" {
+			req.Comment = []byte(desc.Comment)
+		}
+		return c.meta.CreateEdgeIndex(req)
+	})
+}
+
+func (c *defaultMetaClient) DropEdgeIndex(space string, name string, ifExists bool) (types.MetaBaser, error) {
+	return c.execInSpace(space, func(spaceID nthrift.GraphSpaceID) (*meta.ExecResp, error) {
+		return c.meta.DropEdgeIndex(&meta.DropEdgeIndexReq{
+			SpaceID:   spaceID,
+			IndexName: []byte(name),
+			IfExists:  ifExists,
+		})
+	})
+}
+
+// execInSpace runs exec with the id of space, the code of the failed lookup is returned if the space is not found
+func (c *defaultMetaClient) execInSpace(space string, exec func(spaceID nthrift.GraphSpaceID) (*meta.ExecResp, error)) (types.MetaBaser, error) {
+	spaceID, base, err := c.getSpaceID(space)
+	if err != nil {
+		return nil, err
+	}
+	if base.code != nerrors.ErrorCode_SUCCEEDED {
+		return base, nil
+	}
+
+	resp, err := exec(spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMetaBaserWrap(resp.GetCode(), resp.GetLeader()), nil
+}
+
+func newSchema(schema types.Schema) (*meta.Schema, error) {
+	columns, err := newColumnDefs(schema.Columns)
+	if err != nil {
+		return nil, err
+	}
+	prop := &meta.SchemaProp{}
+	if schema.Prop.TTLCol != "" {
+		prop.TtlDuration = &schema.Prop.TTLDuration
+		prop.TtlCol = []byte(schema.Prop.TTLCol)
+	}
+	if schema.Prop.Comment != "" {
+		prop.Comment = []byte(schema.Prop.Comment)
+	}
+	return &meta.Schema{
+		Columns:    columns,
+		SchemaProp: prop,
+	}, nil
+}
+
+func newAlterSchemaItems(items []types.AlterSchemaItem) ([]*meta.AlterSchemaItem, error) {
+	nItems := make([]*meta.AlterSchemaItem, 0, len(items))
+	for _, item := range items {
+		op, err := meta.AlterSchemaOpFromString(string(item.Op))
+		if err != nil {
+			return nil, nerrors.ErrUnsupported
+		}
+		var columns []*meta.ColumnDef
+		if op == meta.AlterSchemaOp_DROP {
+			// the columns to drop are matched by the names
+			columns = make([]*meta.ColumnDef, 0, len(item.Columns))
+			for _, column := range item.Columns {
+				columns = append(columns, &meta.ColumnDef{
+					Name: []byte(column.Name),
+					Type: meta.NewColumnTypeDef(),
+				})
+			}
+		} else if columns, err = newColumnDefs(item.Columns); err != nil {
+			return nil, err
+		}
+		nItems = append(nItems, &meta.AlterSchemaItem{
+			Op: op,
+			Schema: &meta.Schema{
+				Columns:    columns,
+				SchemaProp: &meta.SchemaProp{},
+			},
+		})
+	}
+	return nItems, nil
+}
+
+// newAlterSchemaProp converts prop to alter the ttl and the comment, the ttl is removed if the ttl col is empty
+func newAlterSchemaProp(prop *types.SchemaProp) *meta.SchemaProp {
+	nProp := &meta.SchemaProp{}
+	if prop == nil {
+		return nProp
+	}
+	nProp.TtlDuration = &prop.TTLDuration
+	nProp.TtlCol = []byte(prop.TTLCol)
+	if prop.Comment != "" {
+		nProp.Comment = []byte(prop.Comment)
+	}
+	return nProp
+}
+
+func newColumnDefs(columns []types.ColumnDef) ([]*meta.ColumnDef, error) {
+	defs := make([]*meta.ColumnDef, 0, len(columns))
+	for _, column := range columns {
+		propertyType, err := meta.PropertyTypeFromString(string(column.Type))
+		if err != nil {
+			return nil, nerrors.ErrUnknownPropertyType
+		}
+		def := &meta.ColumnDef{
+			Name: []byte(column.Name),
+			Type: &meta.ColumnTypeDef{
+				Type:       propertyType,
+				TypeLength: column.TypeLength,
+			},
+			Nullable: column.Nullable,
+		}
+		if column.GeoShape != "" {
+			geoShape, err := meta.GeoShapeFromString(column.GeoShape)
+			if err != nil {
+				return nil, nerrors.ErrUnknownPropertyType
+			}
+			def.Type.GeoShape = &geoShape
+		}
+		if column.DefaultValue != nil {
+			if def.DefaultValue, err = encodeDefaultValue(column.DefaultValue); err != nil {
+				return nil, err
+			}
+		}
+		if column.Comment != "" {
+			def.Comment = []byte(column.Comment)
+		}
+		defs = append(defs, def)
+	}
+	return defs, nil
+}
+
+func newIndexFields(fields []types.IndexField) []*meta.IndexFieldDef {
+	defs := make([]*meta.IndexFieldDef, 0, len(fields))
+	for i := range fields {
+		def := &meta.IndexFieldDef{Name: []byte(fields[i].Name)}
+		if fields[i].Length > 0 {
+			def.TypeLength = &fields[i].Length
+		}
+		defs = append(defs, def)
+	}
+	return defs
+}
+
+// encodeDefaultValue encodes value as a constant expression, which is decoded by decodeDefaultValue
+func encodeDefaultValue(value types.Value) ([]byte, error) {
+	b, err := thrift.NewCompactSerializer().Write(value.Unwrap().(*nthrift.Value))
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{constantExprKind}, b...), nil
+}
+
+func (c *defaultMetaClient) SignInService(typ types.ServiceType, clients []types.ServiceClient) (types.MetaBaser, error) {
+	serviceType, err := meta.FTServiceTypeFromString(string(typ))
+	if err != nil {
+		return nil, nerrors.ErrUnsupported
+	}
+	req := &meta.SignInFTServiceReq{
+		Type:    serviceType,
+		Clients: make([]*meta.FTClient, 0, len(clients)),
+	}
+	for _, client := range clients {
+		// the connection type is supported since 3.0
+		if client.ConnType != "" {
+			return nil, nerrors.ErrUnsupported
+		}
+		ftClient := &meta.FTClient{
+			Host: &nthrift.HostAddr{
+				Host: client.Host.Host,
+				Port: client.Host.Port,
+			},
+		}
+		if client.User != "" {
+			ftClient.User = []byte(client.User)
+			ftClient.Pwd = []byte(client.Password)
+		}
+		req.Clients = append(req.Clients, ftClient)
+	}
+	resp, err := c.meta.SignInFTService(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMetaBaserWrap(resp.GetCode(), resp.GetLeader()), nil
+}
+
+func (c *defaultMetaClient) SignOutService(typ types.ServiceType) (types.MetaBaser, error) {
+	if _, err := meta.FTServiceTypeFromString(string(typ)); err != nil {
+		return nil, nerrors.ErrUnsupported
+	}
+	resp, err := c.meta.SignOutFTService(meta.NewSignOutFTServiceReq())
+	if err != nil {
+		return nil, err
+	}
+
+	return newMetaBaserWrap(resp.GetCode(), resp.GetLeader()), nil
+}
+
+func (c *defaultMetaClient) ListServiceClients(typ types.ServiceType) (types.ServiceClients, error) {
+	if _, err := meta.FTServiceTypeFromString(string(typ)); err != nil {
+		return nil, nerrors.ErrUnsupported
+	}
+	resp, err := c.meta.ListFTClients(meta.NewListFTClientsReq())
+	if err != nil {
+		return nil, err
+	}
+
+	return newServiceClientsWrapper(resp.GetCode(), resp.GetLeader(), resp.GetClients()), nil
+}
+
+func (c *defaultMetaClient) AddListener(space string, typ types.ListenerType, endpoints []string) (types.MetaBaser, error) {
+	listenerType, err := meta.ListenerTypeFromString(string(typ))
+	if err != nil {
+		return nil, nerrors.ErrUnsupported
+	}
+	hosts := make([]*nthrift.HostAddr, 0, len(endpoints))
+	for _, ep := range endpoints {
+		host, err := toHostAddr(ep)
+		if err != nil {
+			return nil, err
+		}
+		hosts = append(hosts, host)
+	}
+	return c.execInSpace(space, func(spaceID nthrift.GraphSpaceID) (*meta.ExecResp, error) {
+		return c.meta.AddListener(&meta.AddListenerReq{
+			SpaceID: spaceID,
+			Type:    listenerType,
+			Hosts:   hosts,
+		})
+	})
+}
+
+func (c *defaultMetaClient) RemoveListener(space string, typ types.ListenerType) (types.MetaBaser, error) {
+	listenerType, err := meta.ListenerTypeFromString(string(typ))
+	if err != nil {
+		return nil, nerrors.ErrUnsupported
+	}
+	return c.execInSpace(space, func(spaceID nthrift.GraphSpaceID) (*meta.ExecResp, error) {
+		return c.meta.RemoveListener(&meta.RemoveListenerReq{
+			SpaceID: spaceID,
+			Type:    listenerType,
+		})
+	})
+}
+
+func (c *defaultMetaClient) ListListener(space string) (types.Listeners, error) {
+	spaceID, base, err := c.getSpaceID(space)
+	if err != nil {
+		return nil, err
+	}
+	if base.code != nerrors.ErrorCode_SUCCEEDED {
+		return listenersWrapper{metaBaserWrap: base}, nil
+	}
+
+	resp, err := c.meta.ListListener(&meta.ListListenerReq{SpaceID: spaceID})
+	if err != nil {
+		return nil, err
+	}
+
+	return newListenersWrapper(resp), nil
+}
+
+func (c *defaultMetaClient) CreateFTIndex(space string, desc types.FTIndexDesc) (types.MetaBaser, error) {
+	spaceID, base, err := c.getSpaceID(space)
+	if err != nil {
+		return nil, err
+	}
+	if base.code != nerrors.ErrorCode_SUCCEEDED {
+		return base, nil
+	}
+	schemaID, base, err := c.getSchemaID(spaceID, desc.SchemaName, desc.IsEdge)
+	if err != nil {
+		return nil, err
+	}
+	if base.code != nerrors.ErrorCode_SUCCEEDED {
+		return base, nil
+	}
+
+	req := &meta.CreateFTIndexReq{
+		FulltextIndexName: []byte(desc.Name),
+		Index: &meta.FTIndex{
+			SpaceID:      spaceID,
+			DependSchema: schemaID,
+			Fields:       make([][]byte, 0, len(desc.Fields)),
+		},
+	}
+	for _, field := range desc.Fields {
+		req.Index.Fields = append(req.Index.Fields, []byte(field))
+	}
+	resp, err := c.meta.CreateFTIndex(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMetaBaserWrap(resp.GetCode(), resp.GetLeader()), nil
+}
+
+func (c *defaultMetaClient) DropFTIndex(space string, name string) (types.MetaBaser, error) {
+	return c.execInSpace(space, func(spaceID nthrift.GraphSpaceID) (*meta.ExecResp, error) {
+		return c.meta.DropFTIndex(&meta.DropFTIndexReq{
+			SpaceID:           spaceID,
+			FulltextIndexName: []byte(name),
+		})
+	})
+}
+
+func (c *defaultMetaClient) ListFTIndexes() (types.FTIndexes, error) {
+	resp, err := c.meta.ListFTIndexes(meta.NewListFTIndexesReq())
+	if err != nil {
+		return nil, err
+	}
+
+	return newFTIndexesWrapper(resp), nil
+}
+
+// getSchemaID returns the id of the tag or the edge type, and the code of the failed lookup if it's not found
+func (c *defaultMetaClient) getSchemaID(spaceID nthrift.GraphSpaceID, name string, isEdge bool) (*nthrift.SchemaID, metaBaserWrap, error) {
+	if isEdge {
+		resp, err := c.meta.ListEdges(&meta.ListEdgesReq{SpaceID: spaceID})
+		if err != nil {
+			return nil, metaBaserWrap{}, err
+		}
+		base := newMetaBaserWrap(resp.GetCode(), resp.GetLeader())
+		for _, edge := range resp.GetEdges() {
+			if string(edge.GetEdgeName()) == name {
+				edgeType := edge.GetEdgeType()
+				return &nthrift.SchemaID{EdgeType: &edgeType}, base, nil
+			}
+		}
+		if base.code == nerrors.ErrorCode_SUCCEEDED {
+			base.code = nerrors.ErrorCode_E_EDGE_NOT_FOUND
+		}
+		return nil, base, nil
+	}
+
+	resp, err := c.meta.ListTags(&meta.ListTagsReq{SpaceID: spaceID})
+	if err != nil {
+		return nil, metaBaserWrap{}, err
+	}
+	base := newMetaBaserWrap(resp.GetCode(), resp.GetLeader())
+	for _, tag := range resp.GetTags() {
+		if string(tag.GetTagName()) == name {
+			tagID := tag.GetTagID()
+			return &nthrift.SchemaID{TagID: &tagID}, base, nil
+		}
+	}
+	if base.code == nerrors.ErrorCode_SUCCEEDED {
+		base.code = nerrors.ErrorCode_E_TAG_NOT_FOUND
+	}
+	return nil, base, nil
+}
+
+func toHostAddr(endpoint string) (*nthrift.HostAddr, error) {
+	host, portStr, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return nil, err
+	}
+	return &nthrift.HostAddr{
+		Host: host,
+		Port: nthrift.Port(port),
+	}, nil
+}
+
+func (c *defaultMetaClient) ListTagIndexStatus(space string) (types.IndexStatuses, error) {
+	spaceID, base, err := c.getSpaceID(space)
+	if err != nil {
+		return nil, err
+	}
+	if base.code != nerrors.ErrorCode_SUCCEEDED {
+		return indexStatusesWrapper{metaBaserWrap: base}, nil
+	}
+
+	resp, err := c.meta.ListTagIndexStatus(&meta.ListIndexStatusReq{SpaceID: spaceID})
+	if err != nil {
+		return nil, err
+	}
+
+	return newIndexStatusesWrapper(resp), nil
+}
+
+func (c *defaultMetaClient) ListEdgeIndexStatus(space string) (types.IndexStatuses, error) {
+	spaceID, base, err := c.getSpaceID(space)
+	if err != nil {
+		return nil, err
+	}
+	if base.code != nerrors.ErrorCode_SUCCEEDED {
+		return indexStatusesWrapper{metaBaserWrap: base}, nil
+	}
+
+	resp, err := c.meta.ListEdgeIndexStatus(&meta.ListIndexStatusReq{SpaceID: spaceID})
+	if err != nil {
+		return nil, err
+	}
+
+	return newIndexStatusesWrapper(resp), nil
+}
+
+func (c *defaultMetaClient) RebuildTagIndex(space string, indexes []string) (types.IndexRebuilder, error) {
+	return c.rebuildIndex(space, types.JobRebuildTagIndex, indexes)
+}
+
+func (c *defaultMetaClient) RebuildEdgeIndex(space string, indexes []string) (types.IndexRebuilder, error) {
+	return c.rebuildIndex(space, types.JobRebuildEdgeIndex, indexes)
+}
+
+// rebuildIndex submits the rebuild job of the indexes, all the indexes of space are rebuilt if indexes is empty
+func (c *defaultMetaClient) rebuildIndex(space string, cmd types.JobCmd, indexes []string) (types.IndexRebuilder, error) {
+	resp, err := c.SubmitJob(space, cmd, indexes)
+	if err != nil {
+		return nil, err
+	}
+
+	return indexRebuilderWrapper{
+		JobSubmitted: resp,
+		space:        space,
+		client:       c,
+	}, nil
+}
+
+func (c *defaultMetaClient) GetStats(space string) (types.StatsResult, error) {
+	spaceID, base, err := c.getSpaceID(space)
+	if err != nil {
+		return nil, err
+	}
+	if base.code != nerrors.ErrorCode_SUCCEEDED {
+		return statsResultWrapper{metaBaserWrap: base}, nil
+	}
+
+	resp, err := c.meta.GetStats(&meta.GetStatsReq{SpaceID: spaceID})
+	if err != nil {
+		return nil, err
+	}
+
+	return newStatsResultWrapper(resp), nil
+}
+
+func (c *defaultMetaClient) SubmitJob(space string, cmd types.JobCmd, paras []string) (types.JobSubmitted, error) {
+	adminCmd, ok := jobCmds[cmd]
+	if !ok {
+		return nil, nerrors.ErrUnsupported
+	}
+
+	// the space is the last para of the submitted job
+	jobParas := make([][]byte, 0, len(paras)+1)
+	for _, para := range paras {
+		jobParas = append(jobParas, []byte(para))
+	}
+	req := &meta.AdminJobReq{
+		Op:    meta.AdminJobOp_ADD,
+		Cmd:   adminCmd,
+		Paras: append(jobParas, []byte(space)),
+	}
+	resp, err := c.meta.RunAdminJob(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newJobSubmittedWrapper(resp), nil
+}
+
+func (c *defaultMetaClient) ListJobs(space string) (types.Jobs, error) {
+	req := &meta.AdminJobReq{
+		Op:    meta.AdminJobOp_SHOW_All,
+		Paras: jobParas(space),
+	}
+	resp, err := c.meta.RunAdminJob(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newJobsWrapper(resp), nil
+}
+
+func (c *defaultMetaClient) ShowJob(space string, id int32) (types.JobDetail, error) {
+	req := &meta.AdminJobReq{
+		Op:    meta.AdminJobOp_SHOW,
+		Paras: jobParas(space, strconv.Itoa(int(id))),
+	}
+	resp, err := c.meta.RunAdminJob(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newJobDetailWrapper(resp), nil
+}
+
+func (c *defaultMetaClient) StopJob(space string, id int32) (types.MetaBaser, error) {
+	req := &meta.AdminJobReq{
+		Op:    meta.AdminJobOp_STOP,
+		Paras: jobParas(space, strconv.Itoa(int(id))),
+	}
+	resp, err := c.meta.RunAdminJob(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMetaBaserWrap(resp.GetCode(), resp.GetLeader()), nil
+}
+
+func (c *defaultMetaClient) RecoverJob(space string, ids []int32) (types.JobsRecovered, error) {
+	paras := make([]string, 0, len(ids))
+	for _, id := range ids {
+		paras = append(paras, strconv.Itoa(int(id)))
+	}
+	req := &meta.AdminJobReq{
+		Op:    meta.AdminJobOp_RECOVER,
+		Paras: jobParas(space, paras...),
+	}
+	resp, err := c.meta.RunAdminJob(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return newJobsRecoveredWrapper(resp), nil
+}
+
+// jobParas returns the paras to show, stop and recover the jobs, which are not in any space before 3.0
+func jobParas(_ string, paras ...string) [][]byte {
+	jobParas := make([][]byte, 0, len(paras))
+	for _, para := range paras {
+		jobParas = append(jobParas, []byte(para))
+	}
+	return jobParas
+}
+
+var jobCmds = map[types.JobCmd]meta.AdminCmd{
+	types.JobCompact:          meta.AdminCmd_COMPACT,
+	types.JobFlush:            meta.AdminCmd_FLUSH,
+	types.JobStats:            meta.AdminCmd_STATS,
+	types.JobRebuildTagIndex:  meta.AdminCmd_REBUILD_TAG_INDEX,
+	types.JobRebuildEdgeIndex: meta.AdminCmd_REBUILD_EDGE_INDEX,
+	types.JobDownload:         meta.AdminCmd_DOWNLOAD,
+	types.JobIngest:           meta.AdminCmd_INGEST,
+}
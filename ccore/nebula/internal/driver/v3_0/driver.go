@@ -33,6 +33,10 @@ func (d *defaultDriver) NewStorageClientDriver(transport thrift.Transport, pf th
 	return newStorageAdminClient(transport, pf)
 }
 
+func (d *defaultDriver) NewGraphStorageClientDriver(transport thrift.Transport, pf thrift.ProtocolFactory) types.GraphStorageClientDriver {
+	return newGraphStorageClient(transport, pf)
+}
+
 func (f *defaultFactoryDriver) NewValueBuilder() types.ValueBuilder {
 	builder := nthrift.NewValueBuilder()
 	return &valueBuilder{builder}
@@ -0,0 +1,109 @@
+package v3_0
+
+import (
+	"github.com/facebook/fbthrift/thrift/lib/go/thrift"
+
+	nthrift "github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/internal/thrift/v3_0"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/internal/thrift/v3_0/storage"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
+)
+
+var (
+	_ types.GraphStorageClientDriver = (*defaultGraphStorageClient)(nil)
+)
+
+type (
+	defaultGraphStorageClient struct {
+		graphStorage *storage.GraphStorageServiceClient
+	}
+)
+
+func newGraphStorageClient(transport thrift.Transport, pf thrift.ProtocolFactory) types.GraphStorageClientDriver {
+	return &defaultGraphStorageClient{
+		graphStorage: storage.NewGraphStorageServiceClientFactory(transport, pf),
+	}
+}
+
+func (c *defaultGraphStorageClient) Open() error {
+	return c.graphStorage.Open()
+}
+
+// ScanVertex scans a partition in a request, though the partitions of a space can be scanned together since 3.0
+func (c *defaultGraphStorageClient) ScanVertex(req types.ScanReq) (types.ScanResult, error) {
+	scanReq := &storage.ScanVertexRequest{
+		SpaceID: nthrift.GraphSpaceID(req.SpaceID),
+		Parts:   toScanParts(req),
+		ReturnColumns: []*storage.VertexProp{{
+			Tag:   nthrift.TagID(req.SchemaID),
+			Props: toPropNames(req.Props),
+		}},
+		Limit:                  req.Limit,
+		OnlyLatestVersion:      req.OnlyLatestVersion,
+		EnableReadFromFollower: req.EnableReadFromFollower,
+	}
+	if req.StartTime > 0 {
+		scanReq.StartTime = &req.StartTime
+	}
+	if req.EndTime > 0 {
+		scanReq.EndTime = &req.EndTime
+	}
+	resp, err := c.graphStorage.ScanVertex(scanReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return newScanResultWrapper(req.PartID, resp), nil
+}
+
+func (c *defaultGraphStorageClient) ScanEdge(req types.ScanReq) (types.ScanResult, error) {
+	scanReq := &storage.ScanEdgeRequest{
+		SpaceID: nthrift.GraphSpaceID(req.SpaceID),
+		Parts:   toScanParts(req),
+		ReturnColumns: []*storage.EdgeProp{{
+			Type:  nthrift.EdgeType(req.SchemaID),
+			Props: toPropNames(req.Props),
+		}},
+		Limit:                  req.Limit,
+		OnlyLatestVersion:      req.OnlyLatestVersion,
+		EnableReadFromFollower: req.EnableReadFromFollower,
+	}
+	if req.StartTime > 0 {
+		scanReq.StartTime = &req.StartTime
+	}
+	if req.EndTime > 0 {
+		scanReq.EndTime = &req.EndTime
+	}
+	resp, err := c.graphStorage.ScanEdge(scanReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return newScanResultWrapper(req.PartID, resp), nil
+}
+
+func (c *defaultGraphStorageClient) Close() error {
+	if c.graphStorage != nil {
+		if err := c.graphStorage.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toPropNames(props []string) [][]byte {
+	names := make([][]byte, 0, len(props))
+	for _, prop := range props {
+		names = append(names, []byte(prop))
+	}
+	return names
+}
+
+func toScanParts(req types.ScanReq) map[nthrift.PartitionID]*storage.ScanCursor {
+	cursor := storage.NewScanCursor()
+	if req.Cursor != nil {
+		cursor.NextCursor = req.Cursor
+	}
+	return map[nthrift.PartitionID]*storage.ScanCursor{
+		nthrift.PartitionID(req.PartID): cursor,
+	}
+}
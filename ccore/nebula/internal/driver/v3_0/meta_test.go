@@ -0,0 +1,11 @@
+package v3_0
+
+import (
+	"testing"
+
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/internal/driver/drivertest"
+)
+
+func TestEncodeDefaultValue(t *testing.T) {
+	drivertest.TestDefaultValue(t, &defaultFactoryDriver{}, constantExprKind, encodeDefaultValue, decodeDefaultValue)
+}
@@ -2,6 +2,9 @@ package v3_0
 
 import (
 	"github.com/facebook/fbthrift/thrift/lib/go/thrift"
+
+	nerrors "github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/errors"
+	nthrift "github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/internal/thrift/v3_0"
 	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/internal/thrift/v3_0/storage"
 	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
 )
@@ -26,6 +29,142 @@ func (c *defaultStorageAdminClient) Open() error {
 	return c.storageAdmin.Open()
 }
 
+func (c *defaultStorageAdminClient) TransLeader(spaceID int32, partID int32, newLeader string) (types.StorageAdminResult, error) {
+	leader, err := toHostAddr(newLeader)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.storageAdmin.TransLeader(&storage.TransLeaderReq{
+		SpaceID:    nthrift.GraphSpaceID(spaceID),
+		PartID:     nthrift.PartitionID(partID),
+		NewLeader_: leader,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newStorageAdminResultWrapper(resp.GetResult_()), nil
+}
+
+func (c *defaultStorageAdminClient) AddPart(spaceID int32, partID int32, asLearner bool, peers []string) (types.StorageAdminResult, error) {
+	hosts, err := toHostAddrList(peers)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.storageAdmin.AddPart(&storage.AddPartReq{
+		SpaceID:   nthrift.GraphSpaceID(spaceID),
+		PartID:    nthrift.PartitionID(partID),
+		AsLearner: asLearner,
+		Peers:     hosts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newStorageAdminResultWrapper(resp.GetResult_()), nil
+}
+
+func (c *defaultStorageAdminClient) RemovePart(spaceID int32, partID int32) (types.StorageAdminResult, error) {
+	resp, err := c.storageAdmin.RemovePart(&storage.RemovePartReq{
+		SpaceID: nthrift.GraphSpaceID(spaceID),
+		PartID:  nthrift.PartitionID(partID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newStorageAdminResultWrapper(resp.GetResult_()), nil
+}
+
+func (c *defaultStorageAdminClient) MemberChange(spaceID int32, partID int32, peer string, add bool) (types.StorageAdminResult, error) {
+	host, err := toHostAddr(peer)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.storageAdmin.MemberChange(&storage.MemberChangeReq{
+		SpaceID: nthrift.GraphSpaceID(spaceID),
+		PartID:  nthrift.PartitionID(partID),
+		Peer:    host,
+		Add:     add,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newStorageAdminResultWrapper(resp.GetResult_()), nil
+}
+
+func (c *defaultStorageAdminClient) CreateCheckpoint(spaceIDs []int32, name string) (types.CheckpointResult, error) {
+	resp, err := c.storageAdmin.CreateCheckpoint(&storage.CreateCPRequest{
+		SpaceIds: toGraphSpaceIDs(spaceIDs),
+		Name:     []byte(name),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newCheckpointResultWrapper(resp), nil
+}
+
+func (c *defaultStorageAdminClient) DropCheckpoint(spaceIDs []int32, name string) (types.StorageAdminResult, error) {
+	resp, err := c.storageAdmin.DropCheckpoint(&storage.DropCPRequest{
+		SpaceIds: toGraphSpaceIDs(spaceIDs),
+		Name:     []byte(name),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newStorageAdminResultWrapper(resp.GetResult_()), nil
+}
+
+func (c *defaultStorageAdminClient) BlockingWrites(spaceIDs []int32, sign types.EngineSignType) (types.StorageAdminResult, error) {
+	engineSign, err := toEngineSignType(sign)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.storageAdmin.BlockingWrites(&storage.BlockingSignRequest{
+		SpaceIds: toGraphSpaceIDs(spaceIDs),
+		Sign:     engineSign,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newStorageAdminResultWrapper(resp.GetResult_()), nil
+}
+
+func (c *defaultStorageAdminClient) GetLeaderParts() (types.LeaderParts, error) {
+	resp, err := c.storageAdmin.GetLeaderParts(&storage.GetLeaderReq{})
+	if err != nil {
+		return nil, err
+	}
+
+	return newLeaderPartsWrapper(resp), nil
+}
+
+func (c *defaultStorageAdminClient) CheckPeers(spaceID int32, partID int32, peers []string) (types.StorageAdminResult, error) {
+	hosts, err := toHostAddrList(peers)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.storageAdmin.CheckPeers(&storage.CheckPeersReq{
+		SpaceID: nthrift.GraphSpaceID(spaceID),
+		PartID:  nthrift.PartitionID(partID),
+		Peers:   hosts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newStorageAdminResultWrapper(resp.GetResult_()), nil
+}
+
 func (c *defaultStorageAdminClient) Close() error {
 	if c.storageAdmin != nil {
 		if err := c.storageAdmin.Close(); err != nil {
@@ -34,3 +173,31 @@ func (c *defaultStorageAdminClient) Close() error {
 	}
 	return nil
 }
+
+func toHostAddrList(endpoints []string) ([]*nthrift.HostAddr, error) {
+	hosts := make([]*nthrift.HostAddr, 0, len(endpoints))
+	for _, ep := range endpoints {
+		host, err := toHostAddr(ep)
+		if err != nil {
+			return nil, err
+		}
+		hosts = append(hosts, host)
+	}
+	return hosts, nil
+}
+
+func toGraphSpaceIDs(spaceIDs []int32) []nthrift.GraphSpaceID {
+	ids := make([]nthrift.GraphSpaceID, 0, len(spaceIDs))
+	for _, spaceID := range spaceIDs {
+		ids = append(ids, nthrift.GraphSpaceID(spaceID))
+	}
+	return ids
+}
+
+func toEngineSignType(sign types.EngineSignType) (storage.EngineSignType, error) {
+	engineSign, err := storage.EngineSignTypeFromString(string(sign))
+	if err != nil {
+		return engineSign, nerrors.ErrUnknownEngineSign
+	}
+	return engineSign, nil
+}
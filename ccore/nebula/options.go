@@ -10,6 +10,10 @@ const (
 	DefaultTimeout        = time.Duration(0)
 	DefaultBufferSize     = 128 << 10
 	DefaultFrameMaxLength = math.MaxUint32
+
+	DefaultReconnectMaxAttempts    = 3
+	DefaultReconnectInitialBackoff = 100 * time.Millisecond
+	DefaultReconnectMaxBackoff     = 2 * time.Second
 )
 
 type (
@@ -20,6 +24,16 @@ type (
 		graph        socketOptions
 		meta         socketOptions
 		storageAdmin socketOptions
+		reconnect    ReconnectPolicy
+	}
+
+	// ReconnectPolicy is how the graph client reconnects after the connection is broken.
+	ReconnectPolicy struct {
+		// MaxAttempts is the times to try all the endpoints, the graph client never reconnects if it's not positive
+		MaxAttempts int
+		// InitialBackoff is the wait before the second attempt, and it's doubled for the next ones
+		InitialBackoff time.Duration
+		MaxBackoff     time.Duration
 	}
 
 	socketOptions struct {
@@ -30,6 +44,24 @@ type (
 	}
 
 	Option func(o *Options)
+
+	// StoredOptions are the options which can be persisted, see StoreOptions and WithStoredOptions.
+	StoredOptions struct {
+		Graph        StoredSocketOptions `json:"graph"`
+		Meta         StoredSocketOptions `json:"meta"`
+		StorageAdmin StoredSocketOptions `json:"storageAdmin"`
+		Reconnect    ReconnectPolicy     `json:"reconnect"`
+	}
+
+	// StoredSocketOptions keeps the tls config without its certificates, which are not persisted.
+	StoredSocketOptions struct {
+		Timeout               time.Duration `json:"timeout"`
+		BufferSize            int           `json:"bufferSize"`
+		FrameMaxLength        uint32        `json:"frameMaxLength"`
+		TLS                   bool          `json:"tls"`
+		TLSServerName         string        `json:"tlsServerName,omitempty"`
+		TLSInsecureSkipVerify bool          `json:"tlsInsecureSkipVerify,omitempty"`
+	}
 )
 
 func WithVersion(version Version) Option {
@@ -157,6 +189,43 @@ func WithStorageTLS(tlsConfig *tls.Config) Option {
 	}
 }
 
+func WithReconnectPolicy(policy ReconnectPolicy) Option {
+	return func(o *Options) {
+		o.reconnect = policy
+	}
+}
+
+/*
+`StoreOptions` returns the persistable part of opts,
+the version and logger are not included
+*/
+func StoreOptions(opts ...Option) StoredOptions {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return StoredOptions{
+		Graph:        o.graph.store(),
+		Meta:         o.meta.store(),
+		StorageAdmin: o.storageAdmin.store(),
+		Reconnect:    o.reconnect,
+	}
+}
+
+/*
+`WithStoredOptions` reapplies the options returned by `StoreOptions`,
+tlsConfig is used for the sockets which used tls since the certificates are not stored,
+if it's nil, a tls config with the stored server name and the system roots is used
+*/
+func WithStoredOptions(stored StoredOptions, tlsConfig *tls.Config) Option {
+	return func(o *Options) {
+		o.graph = stored.Graph.restore(tlsConfig)
+		o.meta = stored.Meta.restore(tlsConfig)
+		o.storageAdmin = stored.StorageAdmin.restore(tlsConfig)
+		o.reconnect = stored.Reconnect
+	}
+}
+
 func (o *Options) complete() {
 	defaultOpts := defaultOptions()
 
@@ -166,6 +235,7 @@ func (o *Options) complete() {
 	o.graph.complete()
 	o.meta.complete()
 	o.storageAdmin.complete()
+	o.reconnect.complete()
 }
 
 func (o *Options) validate() error {
@@ -185,6 +255,62 @@ func (o *socketOptions) complete() {
 	}
 }
 
+func (o *socketOptions) store() StoredSocketOptions {
+	stored := StoredSocketOptions{
+		Timeout:        o.timeout,
+		BufferSize:     o.bufferSize,
+		FrameMaxLength: o.frameMaxLength,
+	}
+	if o.tlsConfig != nil {
+		stored.TLS = true
+		stored.TLSServerName = o.tlsConfig.ServerName
+		stored.TLSInsecureSkipVerify = o.tlsConfig.InsecureSkipVerify
+	}
+	return stored
+}
+
+func (o StoredSocketOptions) restore(tlsConfig *tls.Config) socketOptions {
+	restored := socketOptions{
+		timeout:        o.Timeout,
+		bufferSize:     o.BufferSize,
+		frameMaxLength: o.FrameMaxLength,
+	}
+	if o.TLS {
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{
+				ServerName:         o.TLSServerName,
+				InsecureSkipVerify: o.TLSInsecureSkipVerify,
+			}
+		}
+		restored.tlsConfig = tlsConfig
+	}
+	return restored
+}
+
+func (p *ReconnectPolicy) complete() {
+	if p.InitialBackoff < 0 {
+		p.InitialBackoff = 0
+	}
+	if p.MaxBackoff < p.InitialBackoff {
+		p.MaxBackoff = p.InitialBackoff
+	}
+}
+
+// backoff returns the wait before the attempt, which starts from 0
+func (p *ReconnectPolicy) backoff(attempt int) time.Duration {
+	if attempt == 0 {
+		return 0
+	}
+	d := p.InitialBackoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d
+}
+
 func defaultOptions() Options {
 	return Options{
 		version:      versionAuto,
@@ -193,6 +319,15 @@ func defaultOptions() Options {
 		graph:        defaultSocketOptions(),
 		meta:         defaultSocketOptions(),
 		storageAdmin: defaultSocketOptions(),
+		reconnect:    defaultReconnectPolicy(),
+	}
+}
+
+func defaultReconnectPolicy() ReconnectPolicy {
+	return ReconnectPolicy{
+		MaxAttempts:    DefaultReconnectMaxAttempts,
+		InitialBackoff: DefaultReconnectInitialBackoff,
+		MaxBackoff:     DefaultReconnectMaxBackoff,
 	}
 }
 
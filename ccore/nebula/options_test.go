@@ -0,0 +1,118 @@
+package nebula
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStoredOptions(t *testing.T) {
+	tlsConfig := &tls.Config{ServerName: "graphd", InsecureSkipVerify: true}
+	policy := ReconnectPolicy{MaxAttempts: 5, InitialBackoff: time.Second, MaxBackoff: time.Minute}
+
+	cases := []struct {
+		name      string
+		opts      []Option
+		tlsConfig *tls.Config
+	}{
+		{"default", nil, nil},
+		{"timeout", []Option{WithTimeout(time.Second), WithMetaTimeout(2 * time.Second)}, nil},
+		{"socket", []Option{WithGraphBufferSize(1024), WithStorageFrameMaxLength(2048)}, nil},
+		{"reconnect", []Option{WithReconnectPolicy(policy)}, nil},
+		{"tls", []Option{WithGraphTLS(tlsConfig)}, nil},
+		{"tls config", []Option{WithTLS(tlsConfig)}, &tls.Config{ServerName: "restored"}},
+	}
+
+	for _, tc := range cases {
+		expect := defaultOptions()
+		for _, opt := range tc.opts {
+			opt(&expect)
+		}
+
+		// the stored options are persisted as json
+		data, err := json.Marshal(StoreOptions(tc.opts...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var stored StoredOptions
+		if err = json.Unmarshal(data, &stored); err != nil {
+			t.Fatal(err)
+		}
+		restored := defaultOptions()
+		WithStoredOptions(stored, tc.tlsConfig)(&restored)
+
+		for _, pair := range [][2]socketOptions{
+			{expect.graph, restored.graph},
+			{expect.meta, restored.meta},
+			{expect.storageAdmin, restored.storageAdmin},
+		} {
+			want, got := pair[0], pair[1]
+			if got.timeout != want.timeout || got.bufferSize != want.bufferSize || got.frameMaxLength != want.frameMaxLength {
+				t.Errorf("%s: got %+v, want %+v", tc.name, got, want)
+			}
+			if (got.tlsConfig == nil) != (want.tlsConfig == nil) {
+				t.Errorf("%s: got tls %v, want %v", tc.name, got.tlsConfig != nil, want.tlsConfig != nil)
+				continue
+			}
+			if want.tlsConfig == nil {
+				continue
+			}
+			if tc.tlsConfig != nil {
+				if got.tlsConfig != tc.tlsConfig {
+					t.Errorf("%s: the given tls config is not used", tc.name)
+				}
+			} else if got.tlsConfig.ServerName != want.tlsConfig.ServerName || got.tlsConfig.InsecureSkipVerify != want.tlsConfig.InsecureSkipVerify {
+				t.Errorf("%s: got tls config %+v, want %+v", tc.name, got.tlsConfig, want.tlsConfig)
+			}
+		}
+		if !reflect.DeepEqual(restored.reconnect, expect.reconnect) {
+			t.Errorf("%s: got reconnect policy %+v, want %+v", tc.name, restored.reconnect, expect.reconnect)
+		}
+	}
+}
+
+func TestReconnectPolicyBackoff(t *testing.T) {
+	cases := []struct {
+		name   string
+		policy ReconnectPolicy
+		expect []time.Duration
+	}{
+		{
+			name:   "default",
+			policy: defaultReconnectPolicy(),
+			expect: []time.Duration{0, 100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, 1600 * time.Millisecond, 2 * time.Second, 2 * time.Second},
+		},
+		{
+			name:   "capped",
+			policy: ReconnectPolicy{MaxAttempts: 5, InitialBackoff: time.Second, MaxBackoff: 3 * time.Second},
+			expect: []time.Duration{0, time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second},
+		},
+		{
+			name:   "no backoff",
+			policy: ReconnectPolicy{MaxAttempts: 3},
+			expect: []time.Duration{0, 0, 0},
+		},
+		{
+			name:   "negative",
+			policy: ReconnectPolicy{MaxAttempts: 3, InitialBackoff: -time.Second, MaxBackoff: -time.Second},
+			expect: []time.Duration{0, 0, 0},
+		},
+		{
+			name:   "max below initial",
+			policy: ReconnectPolicy{MaxAttempts: 3, InitialBackoff: time.Second, MaxBackoff: time.Millisecond},
+			expect: []time.Duration{0, time.Second, time.Second},
+		},
+	}
+
+	for _, tc := range cases {
+		policy := tc.policy
+		policy.complete()
+		for attempt, want := range tc.expect {
+			if got := policy.backoff(attempt); got != want {
+				t.Errorf("%s: attempt %d got %v, want %v", tc.name, attempt, got, want)
+			}
+		}
+	}
+}
@@ -20,6 +20,7 @@ type (
 		NewGraphClientDriver(thrift.Transport, thrift.ProtocolFactory) GraphClientDriver
 		NewMetaClientDriver(thrift.Transport, thrift.ProtocolFactory) MetaClientDriver
 		NewStorageClientDriver(thrift.Transport, thrift.ProtocolFactory) StorageAdminClientDriver
+		NewGraphStorageClientDriver(thrift.Transport, thrift.ProtocolFactory) GraphStorageClientDriver
 	}
 
 	GraphClientDriver interface {
@@ -43,11 +44,94 @@ type (
 		Balance(req BalanceReq) (Balancer, error)
 		ListHosts() (Hosts, error)
 		ListZones() (Zones, error)
+		ListTags(space string) (SchemaItems, error)
+		GetTag(space string, name string) (SchemaResult, error)
+		ListEdges(space string) (SchemaItems, error)
+		GetEdge(space string, name string) (SchemaResult, error)
+		ListTagIndexes(space string) (IndexItems, error)
+		ListEdgeIndexes(space string) (IndexItems, error)
+		SubmitJob(space string, cmd JobCmd, paras []string) (JobSubmitted, error)
+		ListJobs(space string) (Jobs, error)
+		ShowJob(space string, id int32) (JobDetail, error)
+		StopJob(space string, id int32) (MetaBaser, error)
+		RecoverJob(space string, ids []int32) (JobsRecovered, error)
+		CreateUser(account string, password string, ifNotExists bool) (MetaBaser, error)
+		DropUser(account string, ifExists bool) (MetaBaser, error)
+		AlterUser(account string, password string) (MetaBaser, error)
+		ChangePassword(account string, newPassword string, oldPassword string) (MetaBaser, error)
+		GrantRole(account string, space string, role RoleType) (MetaBaser, error)
+		RevokeRole(account string, space string, role RoleType) (MetaBaser, error)
+		ListUsers() (Users, error)
+		ListRoles(space string) (Roles, error)
+		GetUserRoles(account string) (Roles, error)
+		ListSessions() (Sessions, error)
+		GetSession(id int64) (SessionResult, error)
+		RemoveSession(id int64) (MetaBaser, error)
+		KillQuery(queries map[int64][]int64) (MetaBaser, error)
+		CreateSnapshot() (MetaBaser, error)
+		DropSnapshot(name string) (MetaBaser, error)
+		ListSnapshots() (Snapshots, error)
+		CreateBackup(spaces []string) (BackupResult, error)
+		RestoreMeta(files []string, hosts map[string]string) (MetaBaser, error)
+		ListConfigs(module ConfigModule) (Configs, error)
+		GetConfig(module ConfigModule, name string) (Configs, error)
+		SetConfig(module ConfigModule, name string, value Value) (MetaBaser, error)
+		ListParts(space string, partIDs []int32) (Parts, error)
+		GetZone(zone string) (ZoneResult, error)
+		DropZone(zone string) (MetaBaser, error)
+		MergeZone(zones []string, zone string) (MetaBaser, error)
+		DivideZone(zone string, zoneItems map[string][]string) (MetaBaser, error)
+		RenameZone(zone string, newZone string) (MetaBaser, error)
+		CreateSpace(desc SpaceDesc, ifNotExists bool) (MetaBaser, error)
+		CreateSpaceAs(space string, newSpace string) (MetaBaser, error)
+		DropSpace(space string, ifExists bool) (MetaBaser, error)
+		GetSpace(space string) (SpaceResult, error)
+		AlterSpace(space string, op AlterSpaceOp, paras []string) (MetaBaser, error)
+		CreateTag(space string, name string, schema Schema, ifNotExists bool) (MetaBaser, error)
+		AlterTag(space string, name string, items []AlterSchemaItem, prop *SchemaProp) (MetaBaser, error)
+		DropTag(space string, name string, ifExists bool) (MetaBaser, error)
+		CreateEdge(space string, name string, schema Schema, ifNotExists bool) (MetaBaser, error)
+		AlterEdge(space string, name string, items []AlterSchemaItem, prop *SchemaProp) (MetaBaser, error)
+		DropEdge(space string, name string, ifExists bool) (MetaBaser, error)
+		CreateTagIndex(space string, desc IndexDesc, ifNotExists bool) (MetaBaser, error)
+		DropTagIndex(space string, name string, ifExists bool) (MetaBaser, error)
+		CreateEdgeIndex(space string, desc IndexDesc, ifNotExists bool) (MetaBaser, error)
+		DropEdgeIndex(space string, name string, ifExists bool) (MetaBaser, error)
+		SignInService(typ ServiceType, clients []ServiceClient) (MetaBaser, error)
+		SignOutService(typ ServiceType) (MetaBaser, error)
+		ListServiceClients(typ ServiceType) (ServiceClients, error)
+		AddListener(space string, typ ListenerType, endpoints []string) (MetaBaser, error)
+		RemoveListener(space string, typ ListenerType) (MetaBaser, error)
+		ListListener(space string) (Listeners, error)
+		CreateFTIndex(space string, desc FTIndexDesc) (MetaBaser, error)
+		DropFTIndex(space string, name string) (MetaBaser, error)
+		ListFTIndexes() (FTIndexes, error)
+		ListTagIndexStatus(space string) (IndexStatuses, error)
+		ListEdgeIndexStatus(space string) (IndexStatuses, error)
+		RebuildTagIndex(space string, indexes []string) (IndexRebuilder, error)
+		RebuildEdgeIndex(space string, indexes []string) (IndexRebuilder, error)
+		GetStats(space string) (StatsResult, error)
 		Close() error
 	}
 
 	StorageAdminClientDriver interface {
 		Open() error
+		TransLeader(spaceID int32, partID int32, newLeader string) (StorageAdminResult, error)
+		AddPart(spaceID int32, partID int32, asLearner bool, peers []string) (StorageAdminResult, error)
+		RemovePart(spaceID int32, partID int32) (StorageAdminResult, error)
+		MemberChange(spaceID int32, partID int32, peer string, add bool) (StorageAdminResult, error)
+		CreateCheckpoint(spaceIDs []int32, name string) (CheckpointResult, error)
+		DropCheckpoint(spaceIDs []int32, name string) (StorageAdminResult, error)
+		BlockingWrites(spaceIDs []int32, sign EngineSignType) (StorageAdminResult, error)
+		GetLeaderParts() (LeaderParts, error)
+		CheckPeers(spaceID int32, partID int32, peers []string) (StorageAdminResult, error)
+		Close() error
+	}
+
+	GraphStorageClientDriver interface {
+		Open() error
+		ScanVertex(req ScanReq) (ScanResult, error)
+		ScanEdge(req ScanReq) (ScanResult, error)
 		Close() error
 	}
 
@@ -82,11 +166,132 @@ type (
 		GetSpaces() []Space
 	}
 
-	Balancer interface {
+	SpaceResult interface {
 		MetaBaser
+		GetSpace() SpaceItem
+	}
+
+	Balancer interface {
+		JobSubmitted
 		GetStats() (BalanceStats, error)
 	}
 
+	JobSubmitted interface {
+		MetaBaser
+		GetJobID() int32
+	}
+
+	Jobs interface {
+		MetaBaser
+		GetJobs() []JobDesc
+	}
+
+	JobDetail interface {
+		MetaBaser
+		GetJob() JobDesc
+		GetTasks() []TaskDesc
+	}
+
+	JobsRecovered interface {
+		MetaBaser
+		GetRecoveredJobNum() int32
+	}
+
+	Users interface {
+		MetaBaser
+		GetUsers() []string
+	}
+
+	Roles interface {
+		MetaBaser
+		GetRoles() []RoleItem
+	}
+
+	Sessions interface {
+		MetaBaser
+		GetSessions() []SessionDesc
+	}
+
+	SessionResult interface {
+		MetaBaser
+		GetSession() SessionDesc
+	}
+
+	Snapshots interface {
+		MetaBaser
+		GetSnapshots() []SnapshotDesc
+	}
+
+	BackupResult interface {
+		MetaBaser
+		GetBackup() BackupDesc
+	}
+
+	Configs interface {
+		MetaBaser
+		GetConfigs() []ConfigItem
+	}
+
+	Parts interface {
+		MetaBaser
+		GetParts() []PartItem
+	}
+
+	ServiceClients interface {
+		MetaBaser
+		GetClients() []ServiceClient
+	}
+
+	Listeners interface {
+		MetaBaser
+		GetListeners() []ListenerInfo
+	}
+
+	FTIndexes interface {
+		MetaBaser
+		GetIndexes() []FTIndexItem
+	}
+
+	IndexStatuses interface {
+		MetaBaser
+		GetStatuses() []IndexStatusItem
+	}
+
+	IndexRebuilder interface {
+		JobSubmitted
+		GetStatus() (JobStatus, error)
+	}
+
+	StatsResult interface {
+		MetaBaser
+		GetStats() StatsItem
+	}
+
+	StorageAdminResult interface {
+		GetFailedParts() []PartResult
+		GetLatencyInUs() int64
+	}
+
+	CheckpointResult interface {
+		StorageAdminResult
+		GetCheckpoints() []CheckpointInfo
+	}
+
+	LeaderParts interface {
+		StorageAdminResult
+		// GetLeaderParts maps the spaces to the partitions led by the host
+		GetLeaderParts() map[int32][]int32
+	}
+
+	ScanResult interface {
+		GetFailedParts() []PartResult
+		GetLatencyInUs() int64
+		// GetData is nil if there is no data scanned
+		GetData() DataSet
+		// GetNextCursor is nil if the partition is scanned to the end
+		GetNextCursor() []byte
+	}
+
 	FactoryDriver interface {
 		NewValueBuilder() ValueBuilder
 		NewDateBuilder() DateBuilder
@@ -116,6 +321,26 @@ type (
 		GetZones() []Zone
 	}
 
+	ZoneResult interface {
+		MetaBaser
+		Zone
+	}
+
+	SchemaItems interface {
+		MetaBaser
+		GetItems() []SchemaItem
+	}
+
+	SchemaResult interface {
+		MetaBaser
+		GetSchema() Schema
+	}
+
+	IndexItems interface {
+		MetaBaser
+		GetItems() []IndexItem
+	}
+
 	Coder interface {
 		GetCode() nerrors.ErrorCode
 	}
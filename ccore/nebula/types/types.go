@@ -2,6 +2,8 @@ package types
 
 import (
 	"fmt"
+
+	nerrors "github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/errors"
 )
 
 type Any = interface{}
@@ -416,21 +418,405 @@ type BalanceReq struct {
 }
 
 type (
-	HostStatus   int64
+	HostStatus int64
 )
 
 type HostItem struct {
-	HostAddr HostAddr
-	Status HostStatus
+	HostAddr    HostAddr
+	Status      HostStatus
 	LeaderParts map[string][]int32
-	AllParts map[string][]int32
-	Role int64
-	GitInfoSha []byte
-	ZoneName []byte
-	Version []byte
+	AllParts    map[string][]int32
+	Role        int64
+	GitInfoSha  []byte
+	ZoneName    []byte
+	Version     []byte
 }
 
 type HostAddr struct {
 	Host string
 	Port int32
 }
+
+// PropertyType is the name of a property type, e.g. INT64, FIXED_STRING
+type PropertyType string
+
+type ColumnDef struct {
+	Name       string
+	Type       PropertyType
+	TypeLength int16
+	// GeoShape is the shape of a GEOGRAPHY property, e.g. POINT
+	GeoShape   string
+	Nullable   bool
+	HasDefault bool
+	// DefaultValue is nil if the default is not a constant, e.g. now()
+	DefaultValue Value
+	Comment      string
+}
+
+type SchemaProp struct {
+	TTLDuration int64
+	TTLCol      string
+	Comment     string
+}
+
+type Schema struct {
+	Columns []ColumnDef
+	Prop    SchemaProp
+}
+
+// SchemaItem is a tag or an edge type
+type SchemaItem struct {
+	ID      int32
+	Name    string
+	Version int64
+	Schema  Schema
+}
+
+type IndexItem struct {
+	ID   int32
+	Name string
+	// SchemaID is the tag id or the edge type of the index
+	SchemaID   int32
+	SchemaName string
+	IsEdge     bool
+	Fields     []ColumnDef
+	Comment    string
+}
+
+type (
+	// JobCmd is the name of an admin job command, e.g. DATA_BALANCE
+	JobCmd string
+	// JobStatus is the name of an admin job or task status, e.g. RUNNING
+	JobStatus string
+)
+
+const (
+	JobCompact          = JobCmd("COMPACT")
+	JobFlush            = JobCmd("FLUSH")
+	JobStats            = JobCmd("STATS")
+	JobRebuildTagIndex  = JobCmd("REBUILD_TAG_INDEX")
+	JobRebuildEdgeIndex = JobCmd("REBUILD_EDGE_INDEX")
+	JobDownload         = JobCmd("DOWNLOAD")
+	JobIngest           = JobCmd("INGEST")
+)
+
+const (
+	JobStatusQueue    = JobStatus("QUEUE")
+	JobStatusRunning  = JobStatus("RUNNING")
+	JobStatusFinished = JobStatus("FINISHED")
+	JobStatusFailed   = JobStatus("FAILED")
+	JobStatusStopped  = JobStatus("STOPPED")
+)
+
+type JobDesc struct {
+	ID        int32
+	Cmd       JobCmd
+	Paras     []string
+	Status    JobStatus
+	StartTime int64
+	StopTime  int64
+}
+
+type TaskDesc struct {
+	ID        int32
+	JobID     int32
+	Host      HostAddr
+	Status    JobStatus
+	StartTime int64
+	StopTime  int64
+}
+
+// RoleType is the name of a role, e.g. ADMIN
+type RoleType string
+
+const (
+	RoleGod   = RoleType("GOD")
+	RoleAdmin = RoleType("ADMIN")
+	RoleDBA   = RoleType("DBA")
+	RoleUser  = RoleType("USER")
+	RoleGuest = RoleType("GUEST")
+)
+
+type RoleItem struct {
+	Account string
+	SpaceID int32
+	Role    RoleType
+}
+
+// QueryStatus is the name of a running query status, e.g. KILLING
+type QueryStatus string
+
+// QueryDesc is a running query, the times are in microseconds
+type QueryDesc struct {
+	PlanID    int64
+	Query     string
+	Status    QueryStatus
+	StartTime int64
+	Duration  int64
+	GraphAddr HostAddr
+}
+
+// SessionDesc is a session of graphd, the times are in microseconds
+type SessionDesc struct {
+	ID         int64
+	User       string
+	Space      string
+	GraphAddr  HostAddr
+	ClientIP   string
+	Timezone   int32
+	CreateTime int64
+	UpdateTime int64
+	Queries    []QueryDesc
+}
+
+// SnapshotStatus is the name of a snapshot status, e.g. VALID
+type SnapshotStatus string
+
+const (
+	SnapshotStatusValid   = SnapshotStatus("VALID")
+	SnapshotStatusInvalid = SnapshotStatus("INVALID")
+)
+
+type SnapshotDesc struct {
+	Name   string
+	Status SnapshotStatus
+	// Hosts are the storage hosts with the checkpoints in the form of "ip:port"
+	Hosts []string
+}
+
+type BackupDesc struct {
+	Name       string
+	MetaFiles  []string
+	Full       bool
+	AllSpaces  bool
+	CreateTime int64
+	Spaces     []SpaceBackupDesc
+}
+
+type SpaceBackupDesc struct {
+	ID    int32
+	Name  string
+	Hosts []HostBackupDesc
+}
+
+type HostBackupDesc struct {
+	Host HostAddr
+	// Checkpoints are the paths of the checkpoints on the host
+	Checkpoints []string
+}
+
+type (
+	// ConfigModule is the name of the service a config belongs to, e.g. STORAGE
+	ConfigModule string
+	// ConfigMode is the name of a config mode, e.g. MUTABLE
+	ConfigMode string
+)
+
+const (
+	ConfigModuleAll     = ConfigModule("ALL")
+	ConfigModuleGraph   = ConfigModule("GRAPH")
+	ConfigModuleMeta    = ConfigModule("META")
+	ConfigModuleStorage = ConfigModule("STORAGE")
+
+	ConfigModeImmutable = ConfigMode("IMMUTABLE")
+	ConfigModeReboot    = ConfigMode("REBOOT")
+	ConfigModeMutable   = ConfigMode("MUTABLE")
+	ConfigModeIgnored   = ConfigMode("IGNORED")
+)
+
+type ConfigItem struct {
+	Module ConfigModule
+	Name   string
+	Mode   ConfigMode
+	Value  Value
+}
+
+type PartItem struct {
+	ID int32
+	// Leader is nil if the partition has no leader
+	Leader *HostAddr
+	Peers  []HostAddr
+	// Losts are the peers which are offline
+	Losts []HostAddr
+}
+
+// AlterSpaceOp is the name of an alter space operation, e.g. ADD_ZONE
+type AlterSpaceOp string
+
+const (
+	AlterSpaceAddZone = AlterSpaceOp("ADD_ZONE")
+)
+
+const (
+	DefaultSpaceCharset   = "utf8"
+	DefaultSpaceCollate   = "utf8_bin"
+	DefaultSpaceVidLength = 8
+)
+
+/*
+`SpaceDesc` is the properties of a space, the partition num and replica factor are the defaults of metad if they are 0,
+VidType is FIXED_STRING or INT64, FIXED_STRING(8) is used if it's empty
+*/
+type SpaceDesc struct {
+	Name          string
+	PartitionNum  int32
+	ReplicaFactor int32
+	VidType       PropertyType
+	// VidLength is the length of FIXED_STRING, DefaultSpaceVidLength is used if it's 0
+	VidLength int16
+	Charset   string
+	Collate   string
+	// Zones are the zones to place the partitions, it's supported since 3.0
+	Zones   []string
+	Comment string
+}
+
+type SpaceItem struct {
+	ID   int32
+	Desc SpaceDesc
+}
+
+// AlterSchemaOp is the name of an alter schema operation, e.g. ADD
+type AlterSchemaOp string
+
+const (
+	AlterSchemaAdd    = AlterSchemaOp("ADD")
+	AlterSchemaChange = AlterSchemaOp("CHANGE")
+	AlterSchemaDrop   = AlterSchemaOp("DROP")
+)
+
+// AlterSchemaItem is the columns to add, change or drop, only the names are used to drop
+type AlterSchemaItem struct {
+	Op      AlterSchemaOp
+	Columns []ColumnDef
+}
+
+type IndexField struct {
+	Name string
+	// Length is the prefix length of a string field, it's required by a FIXED_STRING or STRING field
+	Length int16
+}
+
+// IndexDesc is the index to create, SchemaName is the tag or the edge type to index
+type IndexDesc struct {
+	Name       string
+	SchemaName string
+	Fields     []IndexField
+	Comment    string
+}
+
+type (
+	// ServiceType is the name of an external service type, e.g. ELASTICSEARCH
+	ServiceType string
+	// ListenerType is the name of a listener type, e.g. ELASTICSEARCH
+	ListenerType string
+)
+
+const (
+	ServiceElasticsearch  = ServiceType("ELASTICSEARCH")
+	ListenerElasticsearch = ListenerType("ELASTICSEARCH")
+)
+
+type ServiceClient struct {
+	Host     HostAddr
+	User     string
+	Password string
+	// ConnType is http or https, it's supported since 3.0
+	ConnType string
+}
+
+type ListenerInfo struct {
+	Type   ListenerType
+	Host   HostAddr
+	PartID int32
+	Status HostStatus
+}
+
+// FTIndexDesc is the full-text index to create, SchemaName is the tag or the edge type to index
+type FTIndexDesc struct {
+	Name       string
+	SchemaName string
+	IsEdge     bool
+	Fields     []string
+}
+
+// FTIndexItem is a full-text index, SchemaID is the tag id or the edge type of the index
+type FTIndexItem struct {
+	Name     string
+	SpaceID  int32
+	SchemaID int32
+	IsEdge   bool
+	Fields   []string
+}
+
+// IndexStatusItem is the status of the last rebuild job of an index, e.g. FINISHED
+type IndexStatusItem struct {
+	Name   string
+	Status JobStatus
+}
+
+// Correlativity is the proportion of the edges between a partition and PartID
+type Correlativity struct {
+	PartID     int32
+	Proportion float64
+}
+
+/*
+`StatsItem` is the result of the last STATS job of a space,
+the correlativities map each partition to the partitions connected by its out (positive) or in (negative) edges
+*/
+type StatsItem struct {
+	TagVertices               map[string]int64
+	Edges                     map[string]int64
+	SpaceVertices             int64
+	SpaceEdges                int64
+	PositivePartCorrelativity map[int32][]Correlativity
+	NegativePartCorrelativity map[int32][]Correlativity
+	Status                    JobStatus
+}
+
+// PartResult is a partition failed in a storage admin request, Leader is set if the host is not the leader
+type PartResult struct {
+	Code   nerrors.ErrorCode
+	PartID int32
+	Leader *HostAddr
+}
+
+// LogInfo is the last wal log of a partition in a checkpoint
+type LogInfo struct {
+	LogID  int64
+	TermID int64
+}
+
+// CheckpointInfo is a checkpoint created on a storage host, Parts map the partitions to their last wal logs
+type CheckpointInfo struct {
+	SpaceID int32
+	Path    string
+	Parts   map[int32]LogInfo
+}
+
+// EngineSignType is the name of a sign to block the writes of the storage engine, e.g. BLOCK_ON
+type EngineSignType string
+
+const (
+	EngineSignBlockOn  = EngineSignType("BLOCK_ON")
+	EngineSignBlockOff = EngineSignType("BLOCK_OFF")
+)
+
+/*
+`ScanReq` scans a partition of a space from Cursor, or from the beginning if Cursor is nil,
+SchemaID is the tag id or the edge type, and Props are the properties to return, e.g. _vid or _src
+*/
+type ScanReq struct {
+	SpaceID  int32
+	PartID   int32
+	Cursor   []byte
+	SchemaID int32
+	Props    []string
+	Limit    int64
+	// StartTime and EndTime limit the write time of the data if they are positive
+	StartTime              int64
+	EndTime                int64
+	OnlyLatestVersion      bool
+	EnableReadFromFollower bool
+}
@@ -27,6 +27,10 @@ type ValueWrapper struct {
 	timezoneInfo types.TimezoneInfo
 }
 
+func NewValueWrapper(value types.Value, factory types.FactoryDriver, timezoneInfo types.TimezoneInfo) *ValueWrapper {
+	return &ValueWrapper{value, factory, timezoneInfo}
+}
+
 func (valWrap ValueWrapper) IsEmpty() bool {
 	return valWrap.GetType() == "empty"
 }
@@ -0,0 +1,522 @@
+package controllers
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/astaxie/beego"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/gateway/dao"
+)
+
+type AdminController struct {
+	beego.Controller
+}
+
+type SpaceRequest struct {
+	dao.SpaceDesc
+	IfNotExists bool `json:"ifNotExists"`
+}
+
+type CloneSpaceRequest struct {
+	// Name is the new space to create with the schemas of the space
+	Name string `json:"name"`
+}
+
+type SpaceZonesRequest struct {
+	Zones []string `json:"zones"`
+}
+
+type StatsRequest struct {
+	// TimeoutMs bounds the wait for the STATS job, the default is 60s and the max is 10m
+	TimeoutMs int64 `json:"timeoutMs"`
+}
+
+type HostsRequest struct {
+	// Hosts are the storage hosts in the form of "ip:port"
+	Hosts []string `json:"hosts"`
+	// Zone is the zone to add the hosts into, it's optional
+	Zone  string `json:"zone"`
+	IsNew bool   `json:"isNew"`
+}
+
+type ZoneRequest struct {
+	// Zones are the zones to merge
+	Zones []string `json:"zones"`
+	// ZoneItems maps the zones to divide into to their hosts in the form of "ip:port"
+	ZoneItems map[string][]string `json:"zoneItems"`
+	// Name is the new name to rename the zone to
+	Name string `json:"name"`
+}
+
+type ServiceRequest struct {
+	Clients []dao.ServiceClient `json:"clients"`
+}
+
+type ListenerRequest struct {
+	// Type is the listener type, e.g. elasticsearch
+	Type string `json:"type"`
+	// Hosts are the listeners in the form of "ip:port"
+	Hosts []string `json:"hosts"`
+}
+
+type BalanceRequest struct {
+	// Cmd is one of data, leader and dataRemove
+	Cmd   string `json:"cmd"`
+	Space string `json:"space"`
+	// Hosts are the hosts to remove for dataRemove
+	Hosts []string `json:"hosts"`
+}
+
+type JobRequest struct {
+	Space string `json:"space"`
+	// Cmd is the job to submit, e.g. compact, flush, stats or rebuild_tag_index
+	Cmd string `json:"cmd"`
+	// Paras are the index names to rebuild or the hdfs url to download
+	Paras []string `json:"paras"`
+	// IDs are the jobs to recover, all the failed and stopped jobs are recovered if it's empty
+	IDs []int32 `json:"ids"`
+}
+
+type KillQueriesRequest struct {
+	Queries []dao.QueryToKill `json:"queries"`
+}
+
+type SnapshotRequest struct {
+	// Keep is how many newest snapshots to keep, the older ones are dropped after creating if it's positive
+	Keep int `json:"keep"`
+}
+
+type BackupRequest struct {
+	// Spaces are the spaces to back up, all the spaces are backed up if it's empty
+	Spaces []string `json:"spaces"`
+}
+
+type ConfigRequest struct {
+	// Value is a bool, number, string or a map of them
+	Value interface{} `json:"value"`
+}
+
+const (
+	defaultWaitJobTimeout = 60 * time.Second
+	// maxWaitJobTimeout bounds how long a request can hold its meta client and the http connection
+	maxWaitJobTimeout = 10 * time.Minute
+)
+
+func (this *AdminController) ListSpaces() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.ListSpaces(nsid)
+	})
+}
+
+func (this *AdminController) GetSpace() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.GetSpace(nsid, this.Ctx.Input.Param(":space"))
+	})
+}
+
+func (this *AdminController) CreateSpace() {
+	var params SpaceRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		return nil, dao.CreateSpace(nsid, params.SpaceDesc, params.IfNotExists)
+	})
+}
+
+func (this *AdminController) CloneSpace() {
+	var params CloneSpaceRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		return nil, dao.CloneSpace(nsid, this.Ctx.Input.Param(":space"), params.Name)
+	})
+}
+
+func (this *AdminController) DropSpace() {
+	this.serve(func(nsid string) (interface{}, error) {
+		ifExists, err := this.GetBool("ifExists", false)
+		if err != nil {
+			return nil, err
+		}
+		return nil, dao.DropSpace(nsid, this.Ctx.Input.Param(":space"), ifExists)
+	})
+}
+
+func (this *AdminController) AddSpaceZones() {
+	var params SpaceZonesRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		return nil, dao.AddSpaceZones(nsid, this.Ctx.Input.Param(":space"), params.Zones)
+	})
+}
+
+func (this *AdminController) GetStats() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.GetStats(nsid, this.Ctx.Input.Param(":space"))
+	})
+}
+
+// CollectStats submits a STATS job of the space and returns the stats after the job is finished
+func (this *AdminController) CollectStats() {
+	var params StatsRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.CollectStats(nsid, this.Ctx.Input.Param(":space"), waitJobTimeout(params.TimeoutMs))
+	})
+}
+
+func (this *AdminController) ListHosts() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.ListHosts(nsid)
+	})
+}
+
+func (this *AdminController) ListZones() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.ListZones(nsid)
+	})
+}
+
+func (this *AdminController) GetZone() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.GetZone(nsid, this.Ctx.Input.Param(":zone"))
+	})
+}
+
+func (this *AdminController) DropZone() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return nil, dao.DropZone(nsid, this.Ctx.Input.Param(":zone"))
+	})
+}
+
+// MergeZone merges the zones of the request into the zone of the path
+func (this *AdminController) MergeZone() {
+	var params ZoneRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		return nil, dao.MergeZone(nsid, params.Zones, this.Ctx.Input.Param(":zone"))
+	})
+}
+
+func (this *AdminController) DivideZone() {
+	var params ZoneRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		return nil, dao.DivideZone(nsid, this.Ctx.Input.Param(":zone"), params.ZoneItems)
+	})
+}
+
+func (this *AdminController) RenameZone() {
+	var params ZoneRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		return nil, dao.RenameZone(nsid, this.Ctx.Input.Param(":zone"), params.Name)
+	})
+}
+
+func (this *AdminController) AddHosts() {
+	var params HostsRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		return nil, dao.AddHosts(nsid, params.Hosts, params.Zone, params.IsNew)
+	})
+}
+
+func (this *AdminController) DropHosts() {
+	var params HostsRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		return nil, dao.DropHosts(nsid, params.Hosts)
+	})
+}
+
+func (this *AdminController) Balance() {
+	var params BalanceRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.Balance(nsid, params.Cmd, params.Space, params.Hosts)
+	})
+}
+
+func (this *AdminController) ListJobs() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.ListJobs(nsid, this.GetString("space"))
+	})
+}
+
+func (this *AdminController) SubmitJob() {
+	var params JobRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		jobID, err := dao.SubmitJob(nsid, params.Space, params.Cmd, params.Paras)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]int32{"jobId": jobID}, nil
+	})
+}
+
+func (this *AdminController) ShowJob() {
+	this.serve(func(nsid string) (interface{}, error) {
+		id, err := this.jobID()
+		if err != nil {
+			return nil, err
+		}
+		return dao.ShowJob(nsid, this.GetString("space"), id)
+	})
+}
+
+func (this *AdminController) StopJob() {
+	var params JobRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		id, err := this.jobID()
+		if err != nil {
+			return nil, err
+		}
+		return nil, dao.StopJob(nsid, params.Space, id)
+	})
+}
+
+func (this *AdminController) RecoverJob() {
+	var params JobRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		recovered, err := dao.RecoverJob(nsid, params.Space, params.IDs)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]int32{"recovered": recovered}, nil
+	})
+}
+
+/*
+`WaitJob` polls the job until it's finished, failed or stopped,
+the query `timeoutMs` and `intervalMs` limit the polling
+*/
+func (this *AdminController) WaitJob() {
+	this.serve(func(nsid string) (interface{}, error) {
+		id, err := this.jobID()
+		if err != nil {
+			return nil, err
+		}
+		timeoutMs, err := this.GetInt64("timeoutMs", defaultWaitJobTimeout.Milliseconds())
+		if err != nil {
+			return nil, err
+		}
+		intervalMs, err := this.GetInt64("intervalMs", 0)
+		if err != nil {
+			return nil, err
+		}
+		return dao.WaitJob(nsid, this.GetString("space"), id,
+			waitJobTimeout(timeoutMs), time.Duration(intervalMs)*time.Millisecond)
+	})
+}
+
+// waitJobTimeout converts timeoutMs and clamps it to maxWaitJobTimeout, a non-positive one means the default
+func waitJobTimeout(timeoutMs int64) time.Duration {
+	if timeoutMs <= 0 {
+		return defaultWaitJobTimeout
+	}
+	if timeoutMs > maxWaitJobTimeout.Milliseconds() {
+		return maxWaitJobTimeout
+	}
+	return time.Duration(timeoutMs) * time.Millisecond
+}
+
+func (this *AdminController) jobID() (int32, error) {
+	id, err := strconv.ParseInt(this.Ctx.Input.Param(":id"), 10, 32)
+	return int32(id), err
+}
+
+func (this *AdminController) ListSessions() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.ListSessions(nsid)
+	})
+}
+
+func (this *AdminController) ShowSession() {
+	this.serve(func(nsid string) (interface{}, error) {
+		id, err := strconv.ParseInt(this.Ctx.Input.Param(":id"), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return dao.GetSession(nsid, id)
+	})
+}
+
+func (this *AdminController) RemoveSession() {
+	this.serve(func(nsid string) (interface{}, error) {
+		id, err := strconv.ParseInt(this.Ctx.Input.Param(":id"), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return nil, dao.RemoveSession(nsid, id)
+	})
+}
+
+func (this *AdminController) ListQueries() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.ListQueries(nsid)
+	})
+}
+
+func (this *AdminController) KillQueries() {
+	var params KillQueriesRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		return nil, dao.KillQueries(nsid, params.Queries)
+	})
+}
+
+func (this *AdminController) ListSnapshots() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.ListSnapshots(nsid)
+	})
+}
+
+func (this *AdminController) CreateSnapshot() {
+	var params SnapshotRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		snapshot, err := dao.CreateSnapshot(nsid)
+		if err != nil {
+			return nil, err
+		}
+		dropped := make([]string, 0)
+		if params.Keep > 0 {
+			if dropped, err = dao.PruneSnapshots(nsid, params.Keep); err != nil {
+				return nil, err
+			}
+		}
+		return map[string]interface{}{
+			"snapshot": snapshot,
+			"dropped":  dropped,
+		}, nil
+	})
+}
+
+func (this *AdminController) DropSnapshot() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return nil, dao.DropSnapshot(nsid, this.Ctx.Input.Param(":name"))
+	})
+}
+
+func (this *AdminController) PruneSnapshots() {
+	var params SnapshotRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.PruneSnapshots(nsid, params.Keep)
+	})
+}
+
+func (this *AdminController) CreateBackup() {
+	var params BackupRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.CreateBackup(nsid, params.Spaces)
+	})
+}
+
+func (this *AdminController) ListConfigs() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.ListConfigs(nsid, this.GetString("module"))
+	})
+}
+
+func (this *AdminController) GetConfig() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.GetConfig(nsid, this.Ctx.Input.Param(":module"), this.Ctx.Input.Param(":name"))
+	})
+}
+
+func (this *AdminController) SetConfig() {
+	var params ConfigRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		return nil, dao.SetConfig(nsid, this.Ctx.Input.Param(":module"), this.Ctx.Input.Param(":name"), params.Value)
+	})
+}
+
+// DiffConfigs compares the configs in the request body, which maps the modules to the configs
+func (this *AdminController) DiffConfigs() {
+	var desired map[string]map[string]interface{}
+	err := json.Unmarshal(this.Ctx.Input.RequestBody, &desired)
+	this.serve(func(nsid string) (interface{}, error) {
+		if err != nil {
+			return nil, err
+		}
+		return dao.DiffConfigs(nsid, desired)
+	})
+}
+
+/*
+`ListParts` lists the partitions of the space,
+the query `parts=1,2` filters them
+*/
+func (this *AdminController) ListParts() {
+	this.serve(func(nsid string) (interface{}, error) {
+		partIDs := make([]int32, 0)
+		for _, part := range this.GetStrings("parts") {
+			for _, id := range strings.Split(part, ",") {
+				partID, err := strconv.ParseInt(strings.TrimSpace(id), 10, 32)
+				if err != nil {
+					return nil, err
+				}
+				partIDs = append(partIDs, int32(partID))
+			}
+		}
+		return dao.ListParts(nsid, this.Ctx.Input.Param(":space"), partIDs)
+	})
+}
+
+func (this *AdminController) GetPartsReport() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.GetPartsReport(nsid, this.Ctx.Input.Param(":space"))
+	})
+}
+
+func (this *AdminController) ListServiceClients() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.ListServiceClients(nsid, this.Ctx.Input.Param(":type"))
+	})
+}
+
+func (this *AdminController) SignInService() {
+	var params ServiceRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		return nil, dao.SignInService(nsid, this.Ctx.Input.Param(":type"), params.Clients)
+	})
+}
+
+func (this *AdminController) SignOutService() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return nil, dao.SignOutService(nsid, this.Ctx.Input.Param(":type"))
+	})
+}
+
+func (this *AdminController) ListListeners() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.ListListeners(nsid, this.Ctx.Input.Param(":space"))
+	})
+}
+
+func (this *AdminController) AddListener() {
+	var params ListenerRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		return nil, dao.AddListener(nsid, this.Ctx.Input.Param(":space"), params.Type, params.Hosts)
+	})
+}
+
+// RemoveListener removes the listeners of the space, the query `type` is the listener type
+func (this *AdminController) RemoveListener() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return nil, dao.RemoveListener(nsid, this.Ctx.Input.Param(":space"), this.GetString("type"))
+	})
+}
+
+func (this *AdminController) serve(get func(nsid string) (interface{}, error)) {
+	serveWithNsid(&this.Controller, get)
+}
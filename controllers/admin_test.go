@@ -0,0 +1,26 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWaitJobTimeout(t *testing.T) {
+	cases := []struct {
+		timeoutMs int64
+		timeout   time.Duration
+	}{
+		{-1, defaultWaitJobTimeout},
+		{0, defaultWaitJobTimeout},
+		{1, time.Millisecond},
+		{3000, 3 * time.Second},
+		{maxWaitJobTimeout.Milliseconds(), maxWaitJobTimeout},
+		{maxWaitJobTimeout.Milliseconds() + 1, maxWaitJobTimeout},
+		{1 << 62, maxWaitJobTimeout},
+	}
+	for _, tc := range cases {
+		if timeout := waitJobTimeout(tc.timeoutMs); timeout != tc.timeout {
+			t.Errorf("%d: got %v, want %v", tc.timeoutMs, timeout, tc.timeout)
+		}
+	}
+}
@@ -2,6 +2,10 @@ package controllers
 
 import (
 	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/astaxie/beego"
 	"github.com/astaxie/beego/logs"
@@ -9,6 +13,7 @@ import (
 	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/gateway/pool"
 	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
 	"github.com/vesoft-inc/nebula-http-gateway/common"
+	"github.com/vesoft-inc/nebula-http-gateway/service/auth"
 )
 
 type DatabaseController struct {
@@ -27,20 +32,80 @@ type Request struct {
 	Address  string `json:"address"`
 	Port     int    `json:"port"`
 
+	// Addresses are the graphd endpoints in the form of "ip:port", address and port are ignored if it's set
+	Addresses []string `json:"addresses"`
+	// MetaAddresses are the metad endpoints for the schema and admin apis, they must be the trusted ones of the cluster
+	MetaAddresses []string `json:"metaAddresses"`
+
 	/*
 		if the request version field is "",
 		will use `types.VersionHelper()` to infer a version
 	*/
 	Version string `json:"version"`
+
+	// IssueToken asks for a bearer token which can be used instead of the session cookie
+	IssueToken bool `json:"issueToken"`
+}
+
+type ConnectResult struct {
+	Nsid      string `json:"nsid"`
+	Token     string `json:"token"`
+	ExpiresAt int64  `json:"expiresAt"`
 }
 
 type ExecuteRequest struct {
 	Gql       string              `json:"gql"`
 	ParamList types.ParameterList `json:"paramList"`
+	// TimeoutMs bounds the execution, the query is killed when it is exceeded, 0 means no timeout
+	TimeoutMs int64 `json:"timeoutMs"`
+}
+
+type BatchExecuteRequest struct {
+	Statements  []ExecuteRequest `json:"statements"`
+	StopOnError bool             `json:"stopOnError"`
 }
 
 type Data map[string]interface{}
 
+const ndjsonContentType = "application/x-ndjson"
+
+// StreamLine is one line of a streaming execution, a "headers" line and then one "row" line per record.
+type StreamLine struct {
+	Type string    `json:"type"`
+	Data types.Any `json:"data,omitempty"`
+}
+
+// StreamTrailer is the last line of a streaming execution.
+type StreamTrailer struct {
+	Type        string             `json:"type"`
+	Code        int                `json:"code"`
+	Message     string             `json:"message"`
+	TimeCost    int64              `json:"timeCost"`
+	LocalParams types.ParameterMap `json:"localParams"`
+}
+
+// ndjsonWriter encodes every line of a streaming execution and flushes it immediately.
+type ndjsonWriter struct {
+	encoder *json.Encoder
+	flusher http.Flusher
+}
+
+func (w *ndjsonWriter) WriteHeaders(headers []string) error {
+	return w.write(StreamLine{Type: "headers", Data: headers})
+}
+
+func (w *ndjsonWriter) WriteRow(row map[string]types.Any) error {
+	return w.write(StreamLine{Type: "row", Data: row})
+}
+
+func (w *ndjsonWriter) write(line interface{}) error {
+	if err := w.encoder.Encode(line); err != nil {
+		return err
+	}
+	w.flusher.Flush()
+	return nil
+}
+
 func (this *DatabaseController) Connect() {
 	var (
 		res    Response
@@ -48,18 +113,41 @@ func (this *DatabaseController) Connect() {
 	)
 	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
 
-	info, err := dao.Connect(params.Address, params.Port, params.Username, params.Password)
+	var (
+		info *pool.ClientInfo
+		err  error
+	)
+	if len(params.Addresses) > 0 || len(params.MetaAddresses) > 0 {
+		addresses := params.Addresses
+		if len(addresses) == 0 {
+			addresses = []string{strings.Join([]string{params.Address, strconv.Itoa(params.Port)}, ":")}
+		}
+		info, err = dao.ConnectEndpoints(addresses, params.MetaAddresses, params.Username, params.Password)
+	} else {
+		info, err = dao.Connect(params.Address, params.Port, params.Username, params.Password)
+	}
 	if err == nil {
 		nsid := info.ClientID
 		res.Code = 0
-		m := make(map[string]types.Any)
-		m["nsid"] = nsid
 		res.Data = nsid
 		this.Ctx.SetCookie("Secure", "true")
 		this.Ctx.SetCookie("SameSite", "Strict")
 		this.SetSession(beego.AppConfig.String("sessionkey"), nsid)
 
 		res.Message = "Login successfully"
+		if params.IssueToken {
+			token, expiresAt, err := auth.IssueToken(nsid)
+			if err != nil {
+				res.Code = -1
+				res.Message = err.Error()
+			} else {
+				res.Data = ConnectResult{
+					Nsid:      nsid,
+					Token:     token,
+					ExpiresAt: expiresAt,
+				}
+			}
+		}
 	} else {
 		res.Code = -1
 		res.Message = err.Error()
@@ -80,7 +168,7 @@ func (this *DatabaseController) Home() {
 
 func (this *DatabaseController) Disconnect() {
 	var res Response
-	nsid := this.GetSession(beego.AppConfig.String("sessionkey"))
+	nsid := getNsid(&this.Controller)
 	if nsid == nil {
 		res.Code = -1
 		res.Message = "No connection existed"
@@ -93,6 +181,11 @@ func (this *DatabaseController) Disconnect() {
 			res.Code = 0
 			res.Message = "Disconnect successfully"
 		}
+		if token, ok := auth.BearerToken(this.Ctx); ok {
+			if err := auth.RevokeToken(token); err != nil {
+				logs.Error(err)
+			}
+		}
 	}
 
 	this.Data["json"] = &res
@@ -100,15 +193,21 @@ func (this *DatabaseController) Disconnect() {
 }
 
 func (this *DatabaseController) Execute() {
+	if strings.Contains(this.Ctx.Input.Header("Accept"), ndjsonContentType) {
+		this.executeStream()
+		return
+	}
+
 	var res Response
 	var params ExecuteRequest
-	nsid := this.GetSession(beego.AppConfig.String("sessionkey"))
+	nsid := getNsid(&this.Controller)
 	if nsid == nil {
 		res.Code = -1
 		res.Message = "connection refused for lack of session"
 	} else {
 		json.Unmarshal(this.Ctx.Input.RequestBody, &params)
-		result, msg, err := dao.Execute(nsid.(string), params.Gql, params.ParamList)
+		timeout := time.Duration(params.TimeoutMs) * time.Millisecond
+		result, msg, err := dao.ExecuteWithTimeout(nsid.(string), params.Gql, params.ParamList, timeout)
 		if msg != nil {
 			if err == pool.SessionLostError {
 				common.LogPanic(msg)
@@ -128,3 +227,105 @@ func (this *DatabaseController) Execute() {
 	this.Data["json"] = &res
 	this.ServeJSON()
 }
+
+func (this *DatabaseController) BatchExecute() {
+	var res Response
+	var params BatchExecuteRequest
+	nsid := getNsid(&this.Controller)
+	if nsid == nil {
+		res.Code = -1
+		res.Message = "connection refused for lack of session"
+	} else {
+		json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+		items := make([]pool.BatchItem, 0, len(params.Statements))
+		for _, statement := range params.Statements {
+			items = append(items, pool.BatchItem{
+				Gql:       statement.Gql,
+				ParamList: statement.ParamList,
+			})
+		}
+
+		results, err := dao.ExecuteBatch(nsid.(string), items, params.StopOnError)
+		if err == nil {
+			data := make([]Response, 0, len(results))
+			for i := range results {
+				result := &results[i]
+				if result.Msg != nil {
+					if result.Error == pool.SessionLostError {
+						common.LogPanic(result.Msg)
+					} else {
+						logs.Error(result.Msg)
+					}
+				}
+
+				if result.Error == nil {
+					data = append(data, Response{Code: 0, Data: &result.Result})
+				} else {
+					data = append(data, Response{Code: -1, Message: result.Error.Error()})
+				}
+			}
+			res.Code = 0
+			res.Data = data
+		} else {
+			res.Code = -1
+			res.Message = err.Error()
+		}
+	}
+	this.Data["json"] = &res
+	this.ServeJSON()
+}
+
+func (this *DatabaseController) executeStream() {
+	var params ExecuteRequest
+	trailer := StreamTrailer{Type: "trailer"}
+
+	output := this.Ctx.ResponseWriter
+	output.Header().Set("Content-Type", ndjsonContentType+"; charset=utf-8")
+	output.Header().Set("X-Content-Type-Options", "nosniff")
+	output.WriteHeader(http.StatusOK)
+	output.Flush()
+
+	w := &ndjsonWriter{
+		encoder: json.NewEncoder(output),
+		flusher: output,
+	}
+
+	nsid := getNsid(&this.Controller)
+	if nsid == nil {
+		trailer.Code = -1
+		trailer.Message = "connection refused for lack of session"
+	} else {
+		json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+		timeout := time.Duration(params.TimeoutMs) * time.Millisecond
+		result, msg, err := dao.ExecuteStream(nsid.(string), params.Gql, params.ParamList, timeout, w)
+		if msg != nil {
+			if err == pool.SessionLostError {
+				common.LogPanic(msg)
+			} else {
+				logs.Error(msg)
+			}
+		}
+
+		trailer.TimeCost = result.TimeCost
+		trailer.LocalParams = result.LocalParams
+		if err != nil {
+			trailer.Code = -1
+			trailer.Message = err.Error()
+		}
+	}
+
+	if err := w.write(trailer); err != nil {
+		logs.Error(err)
+	}
+}
+
+/*
+returns the nsid bound to the bearer token,
+or the nsid in the session if there is no bearer token
+*/
+func getNsid(c *beego.Controller) interface{} {
+	if nsid := c.Ctx.Input.GetData(auth.NsidKey); nsid != nil {
+		return nsid
+	}
+	return c.GetSession(beego.AppConfig.String("sessionkey"))
+}
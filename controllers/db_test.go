@@ -107,3 +107,48 @@ func TestDBExecute(t *testing.T) {
 		}
 	}
 }
+
+func TestDBBatchExecute(t *testing.T) {
+	cases := []struct {
+		path          string
+		requestMethod string
+		requestBody   []byte
+	}{
+		{
+			"http://127.0.0.1:8080/api/db/batch",
+			"POST",
+			[]byte(`{"statements" : [{"gql" : "SHOW SPACES;"}, {"gql" : "SHOW SPACES11;"}, {"gql" : "SHOW HOSTS;"}],
+					"stopOnError" : true}`),
+		},
+	}
+	for _, tc := range cases {
+		var Response Response
+		req, err := http.NewRequest(tc.requestMethod, tc.path, bytes.NewBuffer(tc.requestBody))
+		if err != nil {
+			t.Fail()
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		client := &http.Client{}
+		resp, err := client.Do(req)
+
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		defer resp.Body.Close()
+
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fail()
+		}
+
+		if err := json.Unmarshal([]byte(body), &Response); err != nil {
+			t.Fail()
+		}
+
+		if Response.Code != -1 && Response.Code != 0 {
+			t.Fail()
+		}
+	}
+}
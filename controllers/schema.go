@@ -0,0 +1,205 @@
+package controllers
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/astaxie/beego"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/gateway/dao"
+)
+
+type SchemaController struct {
+	beego.Controller
+}
+
+type SchemaRequest struct {
+	dao.Schema
+	IfNotExists bool `json:"ifNotExists"`
+}
+
+type RebuildIndexRequest struct {
+	// Type is tag or edge
+	Type string `json:"type"`
+	// Indexes are the indexes to rebuild, all the indexes of the type are rebuilt if it's empty
+	Indexes []string `json:"indexes"`
+}
+
+type IndexRequest struct {
+	dao.IndexDesc
+	IfNotExists bool `json:"ifNotExists"`
+}
+
+func (this *SchemaController) ListTags() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.ListTags(nsid, this.Ctx.Input.Param(":space"))
+	})
+}
+
+func (this *SchemaController) GetTag() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.GetTag(nsid, this.Ctx.Input.Param(":space"), this.Ctx.Input.Param(":name"))
+	})
+}
+
+func (this *SchemaController) CreateTag() {
+	var params SchemaRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		return nil, dao.CreateTag(nsid, this.Ctx.Input.Param(":space"), params.Schema, params.IfNotExists)
+	})
+}
+
+func (this *SchemaController) AlterTag() {
+	var params dao.SchemaAlter
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		return nil, dao.AlterTag(nsid, this.Ctx.Input.Param(":space"), this.Ctx.Input.Param(":name"), params)
+	})
+}
+
+func (this *SchemaController) DropTag() {
+	this.serve(func(nsid string) (interface{}, error) {
+		ifExists, err := this.GetBool("ifExists", false)
+		if err != nil {
+			return nil, err
+		}
+		return nil, dao.DropTag(nsid, this.Ctx.Input.Param(":space"), this.Ctx.Input.Param(":name"), ifExists)
+	})
+}
+
+func (this *SchemaController) ListEdges() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.ListEdges(nsid, this.Ctx.Input.Param(":space"))
+	})
+}
+
+func (this *SchemaController) GetEdge() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.GetEdge(nsid, this.Ctx.Input.Param(":space"), this.Ctx.Input.Param(":name"))
+	})
+}
+
+func (this *SchemaController) CreateEdge() {
+	var params SchemaRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		return nil, dao.CreateEdge(nsid, this.Ctx.Input.Param(":space"), params.Schema, params.IfNotExists)
+	})
+}
+
+func (this *SchemaController) AlterEdge() {
+	var params dao.SchemaAlter
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		return nil, dao.AlterEdge(nsid, this.Ctx.Input.Param(":space"), this.Ctx.Input.Param(":name"), params)
+	})
+}
+
+func (this *SchemaController) DropEdge() {
+	this.serve(func(nsid string) (interface{}, error) {
+		ifExists, err := this.GetBool("ifExists", false)
+		if err != nil {
+			return nil, err
+		}
+		return nil, dao.DropEdge(nsid, this.Ctx.Input.Param(":space"), this.Ctx.Input.Param(":name"), ifExists)
+	})
+}
+
+/*
+`ListIndexes` lists the indexes of the space,
+the query `type=tag` or `type=edge` filters them
+*/
+func (this *SchemaController) ListIndexes() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.ListIndexes(nsid, this.Ctx.Input.Param(":space"), this.GetString("type"))
+	})
+}
+
+func (this *SchemaController) CreateIndex() {
+	var params IndexRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		return nil, dao.CreateIndex(nsid, this.Ctx.Input.Param(":space"), params.IndexDesc, params.IfNotExists)
+	})
+}
+
+// DropIndex drops the index, the query `type` is tag or edge
+func (this *SchemaController) DropIndex() {
+	this.serve(func(nsid string) (interface{}, error) {
+		ifExists, err := this.GetBool("ifExists", false)
+		if err != nil {
+			return nil, err
+		}
+		return nil, dao.DropIndex(nsid, this.Ctx.Input.Param(":space"), this.GetString("type"), this.Ctx.Input.Param(":name"), ifExists)
+	})
+}
+
+// ListIndexStatus lists the rebuild status of the indexes, the query `type=tag` or `type=edge` filters them
+func (this *SchemaController) ListIndexStatus() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.ListIndexStatus(nsid, this.Ctx.Input.Param(":space"), this.GetString("type"))
+	})
+}
+
+func (this *SchemaController) RebuildIndex() {
+	var params RebuildIndexRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.RebuildIndex(nsid, this.Ctx.Input.Param(":space"), params.Type, params.Indexes)
+	})
+}
+
+func (this *SchemaController) GetIndexJob() {
+	this.serve(func(nsid string) (interface{}, error) {
+		id, err := strconv.ParseInt(this.Ctx.Input.Param(":id"), 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		return dao.GetIndexJob(nsid, this.Ctx.Input.Param(":space"), int32(id))
+	})
+}
+
+func (this *SchemaController) ListFTIndexes() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.ListFTIndexes(nsid, this.Ctx.Input.Param(":space"))
+	})
+}
+
+func (this *SchemaController) CreateFTIndex() {
+	var params dao.FTIndex
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		return nil, dao.CreateFTIndex(nsid, this.Ctx.Input.Param(":space"), params)
+	})
+}
+
+func (this *SchemaController) DropFTIndex() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return nil, dao.DropFTIndex(nsid, this.Ctx.Input.Param(":space"), this.Ctx.Input.Param(":name"))
+	})
+}
+
+func (this *SchemaController) serve(get func(nsid string) (interface{}, error)) {
+	serveWithNsid(&this.Controller, get)
+}
+
+// serveWithNsid responds the data got with the nsid of the session
+func serveWithNsid(c *beego.Controller, get func(nsid string) (interface{}, error)) {
+	var res Response
+	nsid := getNsid(c)
+	if nsid == nil {
+		res.Code = -1
+		res.Message = "connection refused for lack of session"
+	} else {
+		data, err := get(nsid.(string))
+		if err == nil {
+			res.Code = 0
+			res.Data = data
+		} else {
+			res.Code = -1
+			res.Message = err.Error()
+		}
+	}
+	c.Data["json"] = &res
+	c.ServeJSON()
+}
@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"encoding/json"
+
+	"github.com/astaxie/beego"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/gateway/dao"
+)
+
+type UserController struct {
+	beego.Controller
+}
+
+type UserRequest struct {
+	Account     string `json:"account"`
+	Password    string `json:"password"`
+	IfNotExists bool   `json:"ifNotExists"`
+	// OldPassword is required to change the password, it's not required to reset it
+	OldPassword string `json:"oldPassword"`
+}
+
+type RoleRequest struct {
+	Account string `json:"account"`
+	Space   string `json:"space"`
+	// Role is one of ADMIN, DBA, USER and GUEST
+	Role string `json:"role"`
+}
+
+func (this *UserController) ListUsers() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.ListUsers(nsid)
+	})
+}
+
+func (this *UserController) CreateUser() {
+	var params UserRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		return nil, dao.CreateUser(nsid, params.Account, params.Password, params.IfNotExists)
+	})
+}
+
+/*
+`AlterUser` resets the password of the user,
+it's changed with the old password if `oldPassword` is set
+*/
+func (this *UserController) AlterUser() {
+	var params UserRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	account := this.Ctx.Input.Param(":account")
+	this.serve(func(nsid string) (interface{}, error) {
+		if params.OldPassword != "" {
+			return nil, dao.ChangePassword(nsid, account, params.Password, params.OldPassword)
+		}
+		return nil, dao.AlterUser(nsid, account, params.Password)
+	})
+}
+
+func (this *UserController) DropUser() {
+	this.serve(func(nsid string) (interface{}, error) {
+		ifExists, err := this.GetBool("ifExists", false)
+		if err != nil {
+			return nil, err
+		}
+		return nil, dao.DropUser(nsid, this.Ctx.Input.Param(":account"), ifExists)
+	})
+}
+
+func (this *UserController) GetUserRoles() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.GetUserRoles(nsid, this.Ctx.Input.Param(":account"))
+	})
+}
+
+func (this *UserController) ListRoles() {
+	this.serve(func(nsid string) (interface{}, error) {
+		return dao.ListRoles(nsid, this.Ctx.Input.Param(":space"))
+	})
+}
+
+func (this *UserController) GrantRole() {
+	var params RoleRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		return nil, dao.GrantRole(nsid, params.Account, params.Space, params.Role)
+	})
+}
+
+func (this *UserController) RevokeRole() {
+	var params RoleRequest
+	json.Unmarshal(this.Ctx.Input.RequestBody, &params)
+	this.serve(func(nsid string) (interface{}, error) {
+		return nil, dao.RevokeRole(nsid, params.Account, params.Space, params.Role)
+	})
+}
+
+func (this *UserController) serve(get func(nsid string) (interface{}, error)) {
+	serveWithNsid(&this.Controller, get)
+}
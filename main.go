@@ -14,6 +14,7 @@ import (
 	"github.com/astaxie/beego/logs"
 	"github.com/vesoft-inc/nebula-http-gateway/common"
 	_ "github.com/vesoft-inc/nebula-http-gateway/routers"
+	"github.com/vesoft-inc/nebula-http-gateway/service/sessionstore"
 
 	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/gateway/pool"
 )
@@ -71,6 +72,29 @@ func main() {
 		logs.GetBeeLogger().Flush()
 	}()
 
+	/*
+		graphd sessions owned by each gateway session, to execute its requests in parallel
+	*/
+	pool.SetSessionWidth(beego.AppConfig.DefaultInt("sessionwidth", 1))
+
+	/*
+		metad services of the cluster, the schema and admin apis only use them if they are set
+	*/
+	pool.SetMetaEndpoints(beego.AppConfig.Strings("metaaddresses"))
+
+	/*
+		persist the gateway sessions, so they can be restored after restarting
+	*/
+	if storeKind := beego.AppConfig.String("sessionstore"); storeKind != "" {
+		store, err := sessionstore.NewStore(storeKind, beego.AppConfig.String("sessionstorepath"))
+		if err != nil {
+			log.Fatalf("create session store %s with error: %s", storeKind, err.Error())
+		}
+		if err = pool.SetSessionStore(store, beego.AppConfig.String("sessionsecret")); err != nil {
+			log.Fatalf("set session store %s with error: %s", storeKind, err.Error())
+		}
+	}
+
 	/*
 		importer file uploads config
 	*/
@@ -3,14 +3,68 @@ package routers
 import (
 	"github.com/astaxie/beego"
 	"github.com/vesoft-inc/nebula-http-gateway/controllers"
+	"github.com/vesoft-inc/nebula-http-gateway/service/auth"
 )
 
 func init() {
+	beego.InsertFilter("/api/*", beego.BeforeExec, auth.Filter)
+
 	beego.Router("/", &controllers.DatabaseController{}, "*:Home")
 	beego.Router("/api/db/connect", &controllers.DatabaseController{}, "POST:Connect")
 	beego.Router("/api/db/exec", &controllers.DatabaseController{}, "POST:Execute")
+	beego.Router("/api/db/batch", &controllers.DatabaseController{}, "POST:BatchExecute")
 	beego.Router("/api/db/disconnect", &controllers.DatabaseController{}, "POST:Disconnect")
 
+	beego.Router("/api/schema/spaces/:space/tags", &controllers.SchemaController{}, "GET:ListTags;POST:CreateTag")
+	beego.Router("/api/schema/spaces/:space/tags/:name", &controllers.SchemaController{}, "GET:GetTag;PUT:AlterTag;DELETE:DropTag")
+	beego.Router("/api/schema/spaces/:space/edges", &controllers.SchemaController{}, "GET:ListEdges;POST:CreateEdge")
+	beego.Router("/api/schema/spaces/:space/edges/:name", &controllers.SchemaController{}, "GET:GetEdge;PUT:AlterEdge;DELETE:DropEdge")
+	beego.Router("/api/schema/spaces/:space/indexes", &controllers.SchemaController{}, "GET:ListIndexes;POST:CreateIndex")
+	beego.Router("/api/schema/spaces/:space/indexes/status", &controllers.SchemaController{}, "GET:ListIndexStatus")
+	beego.Router("/api/schema/spaces/:space/indexes/rebuild", &controllers.SchemaController{}, "POST:RebuildIndex")
+	beego.Router("/api/schema/spaces/:space/indexes/rebuild/:id", &controllers.SchemaController{}, "GET:GetIndexJob")
+	beego.Router("/api/schema/spaces/:space/indexes/:name", &controllers.SchemaController{}, "DELETE:DropIndex")
+	beego.Router("/api/schema/spaces/:space/fulltext_indexes", &controllers.SchemaController{}, "GET:ListFTIndexes;POST:CreateFTIndex")
+	beego.Router("/api/schema/spaces/:space/fulltext_indexes/:name", &controllers.SchemaController{}, "DELETE:DropFTIndex")
+
+	beego.Router("/api/admin/spaces", &controllers.AdminController{}, "GET:ListSpaces;POST:CreateSpace")
+	beego.Router("/api/admin/spaces/:space", &controllers.AdminController{}, "GET:GetSpace;DELETE:DropSpace")
+	beego.Router("/api/admin/spaces/:space/clone", &controllers.AdminController{}, "POST:CloneSpace")
+	beego.Router("/api/admin/spaces/:space/zones", &controllers.AdminController{}, "POST:AddSpaceZones")
+	beego.Router("/api/admin/spaces/:space/stats", &controllers.AdminController{}, "GET:GetStats;POST:CollectStats")
+	beego.Router("/api/admin/spaces/:space/listeners", &controllers.AdminController{}, "GET:ListListeners;POST:AddListener;DELETE:RemoveListener")
+	beego.Router("/api/admin/services/:type", &controllers.AdminController{}, "GET:ListServiceClients;POST:SignInService;DELETE:SignOutService")
+	beego.Router("/api/admin/hosts", &controllers.AdminController{}, "GET:ListHosts;POST:AddHosts;DELETE:DropHosts")
+	beego.Router("/api/admin/zones", &controllers.AdminController{}, "GET:ListZones")
+	beego.Router("/api/admin/zones/:zone", &controllers.AdminController{}, "GET:GetZone;DELETE:DropZone")
+	beego.Router("/api/admin/zones/:zone/merge", &controllers.AdminController{}, "POST:MergeZone")
+	beego.Router("/api/admin/zones/:zone/divide", &controllers.AdminController{}, "POST:DivideZone")
+	beego.Router("/api/admin/zones/:zone/rename", &controllers.AdminController{}, "POST:RenameZone")
+	beego.Router("/api/admin/balance", &controllers.AdminController{}, "POST:Balance")
+	beego.Router("/api/admin/jobs", &controllers.AdminController{}, "GET:ListJobs;POST:SubmitJob")
+	beego.Router("/api/admin/jobs/recover", &controllers.AdminController{}, "POST:RecoverJob")
+	beego.Router("/api/admin/jobs/:id", &controllers.AdminController{}, "GET:ShowJob")
+	beego.Router("/api/admin/jobs/:id/stop", &controllers.AdminController{}, "POST:StopJob")
+	beego.Router("/api/admin/jobs/:id/wait", &controllers.AdminController{}, "GET:WaitJob")
+	beego.Router("/api/admin/users", &controllers.UserController{}, "GET:ListUsers;POST:CreateUser")
+	beego.Router("/api/admin/users/:account", &controllers.UserController{}, "PUT:AlterUser;DELETE:DropUser")
+	beego.Router("/api/admin/users/:account/roles", &controllers.UserController{}, "GET:GetUserRoles")
+	beego.Router("/api/admin/roles", &controllers.UserController{}, "POST:GrantRole;DELETE:RevokeRole")
+	beego.Router("/api/admin/spaces/:space/roles", &controllers.UserController{}, "GET:ListRoles")
+	beego.Router("/api/admin/spaces/:space/parts", &controllers.AdminController{}, "GET:ListParts")
+	beego.Router("/api/admin/spaces/:space/parts/report", &controllers.AdminController{}, "GET:GetPartsReport")
+	beego.Router("/api/admin/sessions", &controllers.AdminController{}, "GET:ListSessions")
+	beego.Router("/api/admin/sessions/:id", &controllers.AdminController{}, "GET:ShowSession;DELETE:RemoveSession")
+	beego.Router("/api/admin/queries", &controllers.AdminController{}, "GET:ListQueries")
+	beego.Router("/api/admin/queries/kill", &controllers.AdminController{}, "POST:KillQueries")
+	beego.Router("/api/admin/snapshots", &controllers.AdminController{}, "GET:ListSnapshots;POST:CreateSnapshot")
+	beego.Router("/api/admin/snapshots/prune", &controllers.AdminController{}, "POST:PruneSnapshots")
+	beego.Router("/api/admin/snapshots/:name", &controllers.AdminController{}, "DELETE:DropSnapshot")
+	beego.Router("/api/admin/backups", &controllers.AdminController{}, "POST:CreateBackup")
+	beego.Router("/api/admin/configs", &controllers.AdminController{}, "GET:ListConfigs")
+	beego.Router("/api/admin/configs/diff", &controllers.AdminController{}, "POST:DiffConfigs")
+	beego.Router("/api/admin/configs/:module/:name", &controllers.AdminController{}, "GET:GetConfig;PUT:SetConfig")
+
 	beego.Router("/api/task/import", &controllers.TaskController{}, "POST:Import")
 	beego.Router("/api/task/import/action", &controllers.TaskController{}, "POST:ImportAction")
 }
@@ -0,0 +1,187 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/astaxie/beego"
+	"github.com/astaxie/beego/context"
+	"github.com/astaxie/beego/logs"
+)
+
+const (
+	NsidKey         = "nsid"
+	bearerPrefix    = "Bearer "
+	defaultTokenTTL = 24 * time.Hour
+)
+
+var (
+	InvalidTokenError = errors.New("invalid token")
+	ExpiredTokenError = errors.New("token expired")
+	RevokedTokenError = errors.New("token revoked")
+
+	// used when `tokensecret` is not configured, tokens become invalid after restart
+	fallbackSecret []byte
+
+	revokedTokens = make(map[string]int64)
+	revokedMux    sync.Mutex
+)
+
+type claims struct {
+	ID        string `json:"jti"`
+	Nsid      string `json:"nsid"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+func init() {
+	fallbackSecret = make([]byte, 32)
+	if _, err := rand.Read(fallbackSecret); err != nil {
+		logs.Emergency(err.Error())
+		panic(err)
+	}
+}
+
+/*
+`IssueToken` signs a bearer token bound to nsid,
+it expires after `tokenexpire` seconds
+*/
+func IssueToken(nsid string) (token string, expiresAt int64, err error) {
+	id := make([]byte, 16)
+	if _, err = rand.Read(id); err != nil {
+		return "", 0, err
+	}
+
+	ttl := defaultTokenTTL
+	if seconds := beego.AppConfig.DefaultInt64("tokenexpire", 0); seconds > 0 {
+		ttl = time.Duration(seconds) * time.Second
+	}
+
+	c := claims{
+		ID:        hex.EncodeToString(id),
+		Nsid:      nsid,
+		ExpiresAt: time.Now().Add(ttl).Unix(),
+	}
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return "", 0, err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + sign(encoded), c.ExpiresAt, nil
+}
+
+/*
+`ParseToken` verifies the signature, expiry and revocation of token,
+and returns the nsid bound to it
+*/
+func ParseToken(token string) (string, error) {
+	c, err := parseClaims(token)
+	if err != nil {
+		return "", err
+	}
+
+	revokedMux.Lock()
+	_, revoked := revokedTokens[c.ID]
+	revokedMux.Unlock()
+	if revoked {
+		return "", RevokedTokenError
+	}
+	return c.Nsid, nil
+}
+
+/*
+`RevokeToken` makes token unusable before it expires
+*/
+func RevokeToken(token string) error {
+	c, err := parseClaims(token)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+	revokedMux.Lock()
+	defer revokedMux.Unlock()
+	// the expired ones would be rejected anyway, no need to remember them
+	for id, expiresAt := range revokedTokens {
+		if expiresAt < now {
+			delete(revokedTokens, id)
+		}
+	}
+	revokedTokens[c.ID] = c.ExpiresAt
+	return nil
+}
+
+/*
+`BearerToken` returns the token in the `Authorization` header
+*/
+func BearerToken(ctx *context.Context) (string, bool) {
+	header := ctx.Input.Header("Authorization")
+	if !strings.HasPrefix(header, bearerPrefix) {
+		return "", false
+	}
+	return strings.TrimSpace(header[len(bearerPrefix):]), true
+}
+
+/*
+`Filter` authenticates the requests with a bearer token,
+and puts the nsid of the token into the input data.
+The requests without a bearer token fall back to the session.
+*/
+func Filter(ctx *context.Context) {
+	token, ok := BearerToken(ctx)
+	if !ok {
+		return
+	}
+
+	nsid, err := ParseToken(token)
+	if err != nil {
+		ctx.Output.JSON(map[string]interface{}{
+			"code":    -1,
+			"data":    nil,
+			"message": err.Error(),
+		}, false, false)
+		return
+	}
+	ctx.Input.SetData(NsidKey, nsid)
+}
+
+func parseClaims(token string) (*claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return nil, InvalidTokenError
+	}
+	if !hmac.Equal([]byte(sign(parts[0])), []byte(parts[1])) {
+		return nil, InvalidTokenError
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, InvalidTokenError
+	}
+	c := &claims{}
+	if err = json.Unmarshal(payload, c); err != nil {
+		return nil, InvalidTokenError
+	}
+	if time.Now().Unix() > c.ExpiresAt {
+		return nil, ExpiredTokenError
+	}
+	return c, nil
+}
+
+func sign(payload string) string {
+	secret := []byte(beego.AppConfig.String("tokensecret"))
+	if len(secret) == 0 {
+		secret = fallbackSecret
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func signedToken(c claims) string {
+	payload, _ := json.Marshal(c)
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + sign(encoded)
+}
+
+func TestParseToken(t *testing.T) {
+	issued, _, err := IssueToken("nsid-issued")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(issued, ".")
+
+	cases := []struct {
+		name  string
+		token string
+		nsid  string
+		err   error
+	}{
+		{"issued", issued, "nsid-issued", nil},
+		{
+			"signed",
+			signedToken(claims{ID: "signed", Nsid: "nsid-signed", ExpiresAt: time.Now().Add(time.Minute).Unix()}),
+			"nsid-signed",
+			nil,
+		},
+		{
+			"expired",
+			signedToken(claims{ID: "expired", Nsid: "nsid-expired", ExpiresAt: time.Now().Add(-time.Minute).Unix()}),
+			"",
+			ExpiredTokenError,
+		},
+		{"tampered payload", base64.RawURLEncoding.EncodeToString([]byte(`{"nsid":"other"}`)) + "." + parts[1], "", InvalidTokenError},
+		{"tampered signature", parts[0] + "." + sign(parts[0]+"x"), "", InvalidTokenError},
+		{"no signature", parts[0], "", InvalidTokenError},
+		{"too many parts", issued + ".x", "", InvalidTokenError},
+		{"not base64", "!!!." + sign("!!!"), "", InvalidTokenError},
+		{"not json", "bm90IGpzb24." + sign("bm90IGpzb24"), "", InvalidTokenError},
+	}
+
+	for _, tc := range cases {
+		nsid, err := ParseToken(tc.token)
+		if err != tc.err {
+			t.Errorf("%s: got error %v, want %v", tc.name, err, tc.err)
+		}
+		if nsid != tc.nsid {
+			t.Errorf("%s: got nsid %q, want %q", tc.name, nsid, tc.nsid)
+		}
+	}
+}
+
+func TestRevokeToken(t *testing.T) {
+	token, _, err := IssueToken("nsid-revoked")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, _, err := IssueToken("nsid-revoked")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RevokeToken(token); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseToken(token); err != RevokedTokenError {
+		t.Errorf("got error %v, want %v", err, RevokedTokenError)
+	}
+	// the other tokens of the same nsid are still valid
+	if nsid, err := ParseToken(other); err != nil || nsid != "nsid-revoked" {
+		t.Errorf("got %q, %v, want %q, nil", nsid, err, "nsid-revoked")
+	}
+
+	if err := RevokeToken("invalid"); err != InvalidTokenError {
+		t.Errorf("got error %v, want %v", err, InvalidTokenError)
+	}
+}
+
+func TestRevokeTokenForgetsExpired(t *testing.T) {
+	revokedMux.Lock()
+	revokedTokens["stale"] = time.Now().Add(-time.Minute).Unix()
+	revokedMux.Unlock()
+
+	token, _, err := IssueToken("nsid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := RevokeToken(token); err != nil {
+		t.Fatal(err)
+	}
+
+	revokedMux.Lock()
+	_, ok := revokedTokens["stale"]
+	revokedMux.Unlock()
+	if ok {
+		t.Error("the expired revoked token is not forgotten")
+	}
+}
@@ -0,0 +1,121 @@
+package sessionstore
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/gateway/pool"
+)
+
+var (
+	_ pool.SessionStore = (*FileStore)(nil)
+
+	// nsid comes from the requests, so make sure it can't escape the directory
+	nsidPattern = regexp.MustCompile(`^[0-9a-fA-F-]+$`)
+
+	InvalidNsidError = errors.New("invalid nsid")
+)
+
+const sessionFileExt = ".json"
+
+type FileStore struct {
+	dir string
+}
+
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) Save(session *pool.StoredSession) error {
+	path, err := s.path(session.Nsid)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	// write to a unique temp file first, so a crash or a concurrent save will not leave a broken session
+	tmpFile, err := ioutil.TempFile(s.dir, session.Nsid+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err = tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err = tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err = tmpFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), path)
+}
+
+func (s *FileStore) Load(nsid string) (*pool.StoredSession, bool, error) {
+	path, err := s.path(nsid)
+	if err != nil {
+		return nil, false, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	session := &pool.StoredSession{}
+	if err = json.Unmarshal(data, session); err != nil {
+		return nil, false, err
+	}
+	return session, true, nil
+}
+
+func (s *FileStore) Delete(nsid string) error {
+	path, err := s.path(nsid)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *FileStore) DeleteExpired(updatedBefore int64) error {
+	files, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), sessionFileExt) {
+			continue
+		}
+		nsid := strings.TrimSuffix(file.Name(), sessionFileExt)
+		session, ok, err := s.Load(nsid)
+		if err != nil || !ok || session.UpdateTime < updatedBefore {
+			_ = s.Delete(nsid)
+		}
+	}
+	return nil
+}
+
+func (s *FileStore) path(nsid string) (string, error) {
+	if !nsidPattern.MatchString(nsid) {
+		return "", InvalidNsidError
+	}
+	return filepath.Join(s.dir, nsid+sessionFileExt), nil
+}
@@ -0,0 +1,67 @@
+package sessionstore
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/gateway/pool"
+)
+
+var _ pool.SessionStore = (*SQLiteStore)(nil)
+
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+func NewSQLiteStore(dbFilePath string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite3", dbFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlStmt := `
+		create table if not exists sessions (nsid text not null primary key, session text, updateTime integer);
+	`
+	if _, err = db.Exec(sqlStmt); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+func (s *SQLiteStore) Save(session *pool.StoredSession) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec("INSERT OR REPLACE INTO sessions(nsid, session, updateTime) values(?,?,?)", session.Nsid, string(data), session.UpdateTime)
+	return err
+}
+
+func (s *SQLiteStore) Load(nsid string) (*pool.StoredSession, bool, error) {
+	var data string
+	err := s.db.QueryRow("SELECT session FROM sessions WHERE nsid=?", nsid).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	session := &pool.StoredSession{}
+	if err = json.Unmarshal([]byte(data), session); err != nil {
+		return nil, false, err
+	}
+	return session, true, nil
+}
+
+func (s *SQLiteStore) Delete(nsid string) error {
+	_, err := s.db.Exec("DELETE FROM sessions WHERE nsid=?", nsid)
+	return err
+}
+
+func (s *SQLiteStore) DeleteExpired(updatedBefore int64) error {
+	_, err := s.db.Exec("DELETE FROM sessions WHERE updateTime<?", updatedBefore)
+	return err
+}
@@ -0,0 +1,23 @@
+package sessionstore
+
+import (
+	"fmt"
+
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/gateway/pool"
+)
+
+/*
+`NewStore` creates the session store of kind,
+"sqlite" keeps the sessions in the sqlite db file of path,
+and "file" keeps each session as a json file in the directory of path
+*/
+func NewStore(kind string, path string) (pool.SessionStore, error) {
+	switch kind {
+	case "sqlite":
+		return NewSQLiteStore(path)
+	case "file":
+		return NewFileStore(path)
+	default:
+		return nil, fmt.Errorf("unknown session store: %s", kind)
+	}
+}
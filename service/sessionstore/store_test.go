@@ -0,0 +1,131 @@
+package sessionstore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/gateway/pool"
+	"github.com/vesoft-inc/nebula-http-gateway/ccore/nebula/types"
+)
+
+func newStoredSession(nsid string, updateTime int64) *pool.StoredSession {
+	return &pool.StoredSession{
+		Nsid:              nsid,
+		Endpoints:         []string{"graphd1:9669", "graphd2:9669"},
+		MetaEndpoints:     []string{"metad:9559"},
+		Username:          "root",
+		EncryptedPassword: []byte{1, 2, 3},
+		Version:           nebula.Version3_0,
+		Options:           &nebula.StoredOptions{},
+		ParameterMap:      types.ParameterMap{},
+		UpdateTime:        updateTime,
+	}
+}
+
+// testStore checks the round trip of the sessions through store
+func testStore(t *testing.T, store pool.SessionStore) {
+	const (
+		nsid    = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+		expired = "6ba7b811-9dad-11d1-80b4-00c04fd430c8"
+	)
+
+	if _, ok, err := store.Load(nsid); err != nil || ok {
+		t.Fatalf("load before save: got %v, %v, want false, nil", ok, err)
+	}
+
+	session := newStoredSession(nsid, 100)
+	if err := store.Save(session); err != nil {
+		t.Fatal(err)
+	}
+	loaded, ok, err := store.Load(nsid)
+	if err != nil || !ok {
+		t.Fatalf("load after save: got %v, %v, want true, nil", ok, err)
+	}
+	if !reflect.DeepEqual(loaded, session) {
+		t.Errorf("got %+v, want %+v", loaded, session)
+	}
+
+	// save again replaces the session
+	session.UpdateTime = 200
+	session.Endpoints = []string{"graphd3:9669"}
+	if err := store.Save(session); err != nil {
+		t.Fatal(err)
+	}
+	if loaded, _, err = store.Load(nsid); err != nil || !reflect.DeepEqual(loaded, session) {
+		t.Errorf("got %+v, %v, want %+v", loaded, err, session)
+	}
+
+	if err := store.Save(newStoredSession(expired, 50)); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.DeleteExpired(150); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := store.Load(expired); err != nil || ok {
+		t.Errorf("load expired: got %v, %v, want false, nil", ok, err)
+	}
+	if _, ok, err := store.Load(nsid); err != nil || !ok {
+		t.Errorf("load unexpired: got %v, %v, want true, nil", ok, err)
+	}
+
+	if err := store.Delete(nsid); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := store.Load(nsid); err != nil || ok {
+		t.Errorf("load after delete: got %v, %v, want false, nil", ok, err)
+	}
+	// deleting a missing session is not an error
+	if err := store.Delete(nsid); err != nil {
+		t.Errorf("delete again: got %v, want nil", err)
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sessionstore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStore(t, store)
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 0 {
+		t.Errorf("got %d files left, want 0", len(files))
+	}
+
+	for _, nsid := range []string{"../escape", "", "a/b"} {
+		if err := store.Save(newStoredSession(nsid, 100)); err != InvalidNsidError {
+			t.Errorf("save %q: got %v, want %v", nsid, err, InvalidNsidError)
+		}
+		if _, ok, err := store.Load(nsid); err != nil || ok {
+			t.Errorf("load %q: got %v, %v, want false, nil", nsid, ok, err)
+		}
+	}
+}
+
+func TestSQLiteStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sessionstore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store, err := NewSQLiteStore(filepath.Join(dir, "sessions.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.db.Close()
+	testStore(t, store)
+}